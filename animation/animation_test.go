@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package animation
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+)
+
+func TestCurves(t *testing.T) {
+	curves := map[string]Curve{
+		"Linear":      Linear,
+		"EaseIn":      EaseIn,
+		"EaseOut":     EaseOut,
+		"EaseInOut":   EaseInOut,
+		"CubicBezier": CubicBezier(.25, .1, .25, 1),
+	}
+	for name, c := range curves {
+		if v := c(0); v != 0 {
+			t.Errorf("%s(0) = %v, want 0", name, v)
+		}
+		if v := c(1); v != 1 {
+			t.Errorf("%s(1) = %v, want 1", name, v)
+		}
+	}
+	// A Bézier with control points on the diagonal is linear.
+	lin := CubicBezier(1./3, 1./3, 2./3, 2./3)
+	for _, x := range []float32{.1, .25, .5, .9} {
+		if y := lin(x); math.Abs(float64(y-x)) > 1e-4 {
+			t.Errorf("linear Bézier(%v) = %v", x, y)
+		}
+	}
+}
+
+func TestSequence(t *testing.T) {
+	s := Sequence{
+		Tween{From: 0, To: 10, Duration: 100 * time.Millisecond},
+		Tween{From: 10, To: 0, Duration: 100 * time.Millisecond, Delay: 50 * time.Millisecond},
+	}
+	if got, want := s.Length(), 250*time.Millisecond; got != want {
+		t.Errorf("length %v, want %v", got, want)
+	}
+	tests := []struct {
+		elapsed time.Duration
+		value   float32
+	}{
+		{-time.Second, 0},
+		{50 * time.Millisecond, 5},
+		{120 * time.Millisecond, 10},
+		{200 * time.Millisecond, 5},
+		{time.Second, 0},
+	}
+	for _, test := range tests {
+		if got := s.At(test.elapsed); got != test.value {
+			t.Errorf("value at %v = %v, want %v", test.elapsed, got, test.value)
+		}
+	}
+}
+
+func TestPlayerInvalidates(t *testing.T) {
+	var (
+		ops op.Ops
+		p   Player
+	)
+	gtx := layout.Context{Ops: &ops, Now: time.Unix(0, 0)}
+	tw := Tween{From: 0, To: 1, Duration: time.Second}
+	p.Start(gtx.Now)
+	gtx.Now = gtx.Now.Add(time.Second / 2)
+	if got := p.Value(gtx, tw); got != .5 {
+		t.Errorf("value %v halfway, want .5", got)
+	}
+	if !p.Playing(gtx.Now, tw) {
+		t.Error("player stopped halfway")
+	}
+	gtx.Now = gtx.Now.Add(time.Second)
+	if got := p.Value(gtx, tw); got != 1 {
+		t.Errorf("value %v after the end, want 1", got)
+	}
+	if p.Playing(gtx.Now, tw) {
+		t.Error("player playing after the end")
+	}
+}
+
+func TestTransitionReverse(t *testing.T) {
+	now := time.Unix(0, 0)
+	tr := Transition{Duration: 100 * time.Millisecond, Curve: EaseInOut}
+	tr.Set(now, true)
+	now = now.Add(30 * time.Millisecond)
+	before := tr.Progress(now)
+	// Reversing continues from the current progress.
+	tr.Set(now, false)
+	if after := tr.Progress(now); after != before {
+		t.Errorf("progress jumped from %v to %v when reversed", before, after)
+	}
+	now = now.Add(20 * time.Millisecond)
+	if got := tr.Progress(now); got >= before {
+		t.Errorf("progress %v did not decrease from %v", got, before)
+	}
+	now = now.Add(10 * time.Millisecond)
+	if tr.Animating(now) {
+		t.Error("transition animating after returning")
+	}
+	if got := tr.Progress(now); got != 0 {
+		t.Errorf("progress %v after returning, want 0", got)
+	}
+}
+
+func TestSpring(t *testing.T) {
+	for _, ratio := range []float32{.3, 1, 2} {
+		s := Spring{DampingRatio: ratio}
+		now := time.Unix(0, 0)
+		s.Step(now, 0)
+		var max float32
+		for i := 0; i < 200; i++ {
+			now = now.Add(16 * time.Millisecond)
+			if v := s.Step(now, 100); v > max {
+				max = v
+			}
+			if s.Settled() {
+				break
+			}
+		}
+		if !s.Settled() || s.Value() != 100 {
+			t.Errorf("ratio %v: spring at %v, want settled at 100", ratio, s.Value())
+		}
+		if overshoot := max > 100; overshoot != (ratio < 1) {
+			t.Errorf("ratio %v: maximum value %v", ratio, max)
+		}
+	}
+}
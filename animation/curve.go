@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package animation
+
+// Curve maps the linear progress of an animation, in the range [0,1],
+// to eased progress. Curves map 0 to 0 and 1 to 1, but may overshoot
+// in between.
+type Curve func(t float32) float32
+
+// Linear progresses at a constant rate.
+func Linear(t float32) float32 {
+	return t
+}
+
+// EaseIn starts slowly and accelerates.
+func EaseIn(t float32) float32 {
+	return t * t * t
+}
+
+// EaseOut starts quickly and decelerates.
+func EaseOut(t float32) float32 {
+	t = 1 - t
+	return 1 - t*t*t
+}
+
+// EaseInOut accelerates until halfway and then decelerates.
+func EaseInOut(t float32) float32 {
+	if t < .5 {
+		return 4 * t * t * t
+	}
+	t = -2*t + 2
+	return 1 - t*t*t/2
+}
+
+// CubicBezier returns the curve of the cubic Bézier from (0,0) to (1,1)
+// with control points (x1,y1) and (x2,y2), as in CSS. The x coordinates
+// must be in the range [0,1].
+func CubicBezier(x1, y1, x2, y2 float32) Curve {
+	bezier := func(t, p1, p2 float32) float32 {
+		// B(t) = 3(1-t)²t p1 + 3(1-t)t² p2 + t³.
+		u := 1 - t
+		return 3*u*u*t*p1 + 3*u*t*t*p2 + t*t*t
+	}
+	slope := func(t, p1, p2 float32) float32 {
+		u := 1 - t
+		return 3*u*u*p1 + 6*u*t*(p2-p1) + 3*t*t*(1-p2)
+	}
+	return func(x float32) float32 {
+		if x <= 0 || x >= 1 {
+			return x
+		}
+		// Solve bezier(t) = x for t with Newton's method, and fall
+		// back to bisection where the slope is too flat.
+		t := x
+		for i := 0; i < 8; i++ {
+			d := bezier(t, x1, x2) - x
+			if -1e-6 < d && d < 1e-6 {
+				return bezier(t, y1, y2)
+			}
+			s := slope(t, x1, x2)
+			if -1e-6 < s && s < 1e-6 {
+				break
+			}
+			t -= d / s
+		}
+		lo, hi := float32(0), float32(1)
+		t = x
+		for i := 0; i < 32; i++ {
+			if bezier(t, x1, x2) < x {
+				lo = t
+			} else {
+				hi = t
+			}
+			t = (lo + hi) / 2
+		}
+		return bezier(t, y1, y2)
+	}
+}
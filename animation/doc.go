@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+/*
+Package animation implements the animation of values over time.
+
+A Tween moves a value between two others over a duration, eased by a
+Curve, and a Sequence plays tracks such as tweens one after another. A
+Player plays a track from the time it is started. A Transition animates
+back and forth between an off and an on state, and a Spring follows a
+moving target with physical motion.
+
+Animations are functions of time: the methods that take a time, such as
+Transition.Progress, are pure, while the methods that take a
+layout.Context use its Now field and invalidate the frame while the
+animation is in progress. Tests control time by choosing the times or
+the Context.Now passed to the animations.
+
+For example, to fade a widget in and out:
+
+	fade := &animation.Transition{Duration: 200 * time.Millisecond, Curve: animation.EaseOut}
+	...
+	// In every frame.
+	alpha := fade.Update(gtx, visible)
+*/
+package animation
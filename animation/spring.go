@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package animation
+
+import (
+	"math"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+)
+
+// Spring animates a value towards a target as if pulled by a damped
+// spring. Unlike fixed-duration animations, a spring preserves its
+// velocity when the target changes.
+type Spring struct {
+	// Stiffness is the spring constant per unit mass. Stiffer springs
+	// are faster. If zero, a default stiffness is used.
+	Stiffness float32
+	// DampingRatio is the damping relative to critical damping. A
+	// ratio of 1 approaches the target as fast as possible without
+	// oscillating, smaller ratios overshoot and oscillate. If zero,
+	// a ratio of 1 is used.
+	DampingRatio float32
+
+	value, velocity float32
+	target          float32
+	last            time.Time
+}
+
+const (
+	defaultStiffness = 400
+	// restThreshold is the distance and speed per second below which
+	// a spring is at rest.
+	restThreshold = 1e-3
+)
+
+// Update the target and return the value at gtx.Now. The frame is
+// invalidated while the spring is moving.
+func (s *Spring) Update(gtx layout.Context, target float32) float32 {
+	v := s.Step(gtx.Now, target)
+	if !s.Settled() {
+		op.InvalidateOp{}.Add(gtx.Ops)
+	}
+	return v
+}
+
+// Step advances the spring to time now with the target and returns its
+// value. The first Step jumps to the target.
+func (s *Spring) Step(now time.Time, target float32) float32 {
+	if s.last.IsZero() {
+		s.value = target
+	} else {
+		s.advance(now.Sub(s.last))
+	}
+	s.last = now
+	s.target = target
+	if s.Settled() {
+		s.value = s.target
+		s.velocity = 0
+	}
+	return s.value
+}
+
+// Set the value and stop the spring.
+func (s *Spring) Set(v float32) {
+	s.value, s.target = v, v
+	s.velocity = 0
+}
+
+// Value returns the value after the last Step.
+func (s *Spring) Value() float32 {
+	return s.value
+}
+
+// Velocity returns the velocity per second after the last Step.
+func (s *Spring) Velocity() float32 {
+	return s.velocity
+}
+
+// Settled reports whether the spring is at rest at its target.
+func (s *Spring) Settled() bool {
+	d := s.value - s.target
+	return -restThreshold < d && d < restThreshold &&
+		-restThreshold < s.velocity && s.velocity < restThreshold
+}
+
+// advance moves the spring by dt with the analytic solution of the
+// damped harmonic oscillator.
+func (s *Spring) advance(dt time.Duration) {
+	if dt <= 0 {
+		return
+	}
+	k := float64(s.Stiffness)
+	if k <= 0 {
+		k = defaultStiffness
+	}
+	zeta := float64(s.DampingRatio)
+	if zeta <= 0 {
+		zeta = 1
+	}
+	w0 := math.Sqrt(k)
+	t := dt.Seconds()
+	x0 := float64(s.value - s.target)
+	v0 := float64(s.velocity)
+	var x, v float64
+	switch {
+	case zeta < 1:
+		wd := w0 * math.Sqrt(1-zeta*zeta)
+		a, b := x0, (v0+zeta*w0*x0)/wd
+		e := math.Exp(-zeta * w0 * t)
+		sin, cos := math.Sincos(wd * t)
+		x = e * (a*cos + b*sin)
+		v = e * ((b*wd-zeta*w0*a)*cos - (a*wd+zeta*w0*b)*sin)
+	case zeta == 1:
+		b := v0 + w0*x0
+		e := math.Exp(-w0 * t)
+		x = (x0 + b*t) * e
+		v = (v0 - w0*b*t) * e
+	default:
+		d := w0 * math.Sqrt(zeta*zeta-1)
+		r1, r2 := -zeta*w0+d, -zeta*w0-d
+		c2 := (v0 - r1*x0) / (r2 - r1)
+		c1 := x0 - c2
+		e1, e2 := math.Exp(r1*t), math.Exp(r2*t)
+		x = c1*e1 + c2*e2
+		v = r1*c1*e1 + r2*c2*e2
+	}
+	s.value = s.target + float32(x)
+	s.velocity = float32(v)
+}
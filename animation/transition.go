@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package animation
+
+import (
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+)
+
+// Transition animates the progress between two states, such as the
+// off and on states of a switch. A transition reversed halfway
+// returns from where it is, without jumping.
+type Transition struct {
+	// Duration of a complete transition.
+	Duration time.Duration
+	// Curve eases the transition. If nil, Linear is used.
+	Curve Curve
+
+	on bool
+	// progress is the linear progress at time at.
+	progress float32
+	at       time.Time
+}
+
+// Update the target state and return the eased progress towards the
+// on state at gtx.Now. The frame is invalidated while the transition
+// is in progress.
+func (t *Transition) Update(gtx layout.Context, on bool) float32 {
+	t.Set(gtx.Now, on)
+	if t.Animating(gtx.Now) {
+		op.InvalidateOp{}.Add(gtx.Ops)
+	}
+	return t.Progress(gtx.Now)
+}
+
+// Set the target state at time now.
+func (t *Transition) Set(now time.Time, on bool) {
+	if on == t.on {
+		return
+	}
+	t.progress = t.linear(now)
+	t.at = now
+	t.on = on
+}
+
+// Jump to state on, without animation.
+func (t *Transition) Jump(on bool) {
+	t.on = on
+	t.progress = 0
+	if on {
+		t.progress = 1
+	}
+}
+
+// On returns the target state.
+func (t *Transition) On() bool {
+	return t.on
+}
+
+// Animating reports whether the transition is in progress at time now.
+func (t *Transition) Animating(now time.Time) bool {
+	p := t.linear(now)
+	return t.on && p < 1 || !t.on && p > 0
+}
+
+// Progress returns the eased progress towards the on state at time
+// now.
+func (t *Transition) Progress(now time.Time) float32 {
+	p := t.linear(now)
+	if t.Curve != nil {
+		p = t.Curve(p)
+	}
+	return p
+}
+
+// linear returns the progress before easing.
+func (t *Transition) linear(now time.Time) float32 {
+	target := float32(0)
+	if t.on {
+		target = 1
+	}
+	if t.Duration <= 0 {
+		return target
+	}
+	d := float32(now.Sub(t.at)) / float32(t.Duration)
+	if d < 0 {
+		d = 0
+	}
+	if t.on {
+		if p := t.progress + d; p < 1 {
+			return p
+		}
+		return 1
+	}
+	if p := t.progress - d; p > 0 {
+		return p
+	}
+	return 0
+}
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package animation
+
+import (
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+)
+
+// Track is a value that changes over a fixed length of time.
+type Track interface {
+	// At returns the value after the elapsed time. Elapsed times
+	// outside [0, Length] return the values at the ends.
+	At(elapsed time.Duration) float32
+	// Length returns the duration of the track.
+	Length() time.Duration
+}
+
+// Tween is a Track that moves from one value to another.
+type Tween struct {
+	From, To float32
+	// Duration of the movement.
+	Duration time.Duration
+	// Delay before the movement starts.
+	Delay time.Duration
+	// Curve eases the movement. If nil, Linear is used.
+	Curve Curve
+}
+
+// Sequence is a Track that plays its tracks one after another.
+type Sequence []Track
+
+// Player plays a Track from the time it is started.
+type Player struct {
+	start   time.Time
+	started bool
+}
+
+// At implements Track.
+func (t Tween) At(elapsed time.Duration) float32 {
+	elapsed -= t.Delay
+	var p float32
+	switch {
+	case elapsed <= 0:
+		p = 0
+	case elapsed >= t.Duration:
+		p = 1
+	default:
+		p = float32(elapsed) / float32(t.Duration)
+	}
+	if t.Curve != nil {
+		p = t.Curve(p)
+	}
+	return t.From + (t.To-t.From)*p
+}
+
+// Length implements Track.
+func (t Tween) Length() time.Duration {
+	return t.Delay + t.Duration
+}
+
+// At implements Track.
+func (s Sequence) At(elapsed time.Duration) float32 {
+	if len(s) == 0 {
+		return 0
+	}
+	for _, t := range s {
+		l := t.Length()
+		if elapsed < l {
+			return t.At(elapsed)
+		}
+		elapsed -= l
+	}
+	last := s[len(s)-1]
+	return last.At(last.Length())
+}
+
+// Length implements Track.
+func (s Sequence) Length() time.Duration {
+	var l time.Duration
+	for _, t := range s {
+		l += t.Length()
+	}
+	return l
+}
+
+// Start playing from the beginning at time now.
+func (p *Player) Start(now time.Time) {
+	p.start = now
+	p.started = true
+}
+
+// Reset the player to the beginning, without playing.
+func (p *Player) Reset() {
+	p.started = false
+}
+
+// Elapsed returns the time played at time now.
+func (p *Player) Elapsed(now time.Time) time.Duration {
+	if !p.started {
+		return 0
+	}
+	return now.Sub(p.start)
+}
+
+// Playing reports whether t is still playing at time now.
+func (p *Player) Playing(now time.Time, t Track) bool {
+	return p.started && p.Elapsed(now) < t.Length()
+}
+
+// Value returns the value of t at gtx.Now, and invalidates the frame
+// while t is playing.
+func (p *Player) Value(gtx layout.Context, t Track) float32 {
+	if p.Playing(gtx.Now, t) {
+		op.InvalidateOp{}.Add(gtx.Ops)
+	}
+	return t.At(p.Elapsed(gtx.Now))
+}
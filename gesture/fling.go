@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package gesture
+
+import (
+	"math"
+	"time"
+
+	"gioui.org/f32"
+	"gioui.org/internal/fling"
+	"gioui.org/unit"
+)
+
+// Velocity estimates the velocity of a moving pointer from its
+// positions, for starting a Fling when the pointer is released. The
+// zero value is ready to use. For 1-dimensional motion, sample the
+// coordinate of interest in X and leave Y zero.
+type Velocity struct {
+	x, y fling.Extrapolation
+}
+
+// Fling moves a point with a velocity that decays over time, such as
+// the content of a scroller after a flick. The zero value uses the
+// platform defaults for friction and velocity thresholds. For
+// 1-dimensional motion, start it with a velocity where only X is
+// non-zero.
+type Fling struct {
+	// Friction is the rate at which the velocity decays, per second.
+	// Higher friction stops a fling sooner. If zero, the platform
+	// default is used.
+	Friction float32
+	// MinVelocity is the speed, per second, below which Start
+	// doesn't start a fling. If zero, a default of 50dp is used.
+	MinVelocity unit.Dp
+	// MaxVelocity is the speed, per second, a fling is limited to. If
+	// zero, a default of 8000dp is used.
+	MaxVelocity unit.Dp
+	// StopVelocity is the speed, per second, at which an active fling
+	// comes to rest. If zero, a fling stops below 1 pixel per second.
+	StopVelocity unit.Dp
+
+	// t0 is the start time.
+	t0 time.Time
+	// v0 is the initial velocity in pixels per second.
+	v0 f32.Point
+	// k is the decay constant of the active fling.
+	k float32
+	// end is the duration of the active fling.
+	end time.Duration
+	// x is the distance covered at the last Tick.
+	x f32.Point
+}
+
+const (
+	// dp/second.
+	defaultMinFlingVelocity = unit.Dp(50)
+	defaultMaxFlingVelocity = unit.Dp(8000)
+)
+
+// Reset the velocity estimate.
+func (v *Velocity) Reset() {
+	*v = Velocity{}
+}
+
+// Sample adds the position of the pointer at time t, typically the
+// Time and Position of a pointer.Event.
+func (v *Velocity) Sample(t time.Duration, pos f32.Point) {
+	v.x.Sample(t, pos.X)
+	v.y.Sample(t, pos.Y)
+}
+
+// Velocity returns the estimated velocity in pixels per second,
+// in the direction of the pointer movement.
+func (v *Velocity) Velocity() f32.Point {
+	// The estimates are in the direction opposite of the movement,
+	// which is the direction of scrolling.
+	return f32.Pt(-v.x.Estimate().Velocity, -v.y.Estimate().Velocity)
+}
+
+// Start a fling with a velocity in pixels per second. The velocity is
+// limited to MaxVelocity, and Start reports false without starting a
+// fling if its speed is below MinVelocity.
+func (f *Fling) Start(c unit.Metric, now time.Time, velocity f32.Point) bool {
+	f.Stop()
+	speed := length(velocity)
+	min := f.MinVelocity
+	if min == 0 {
+		min = defaultMinFlingVelocity
+	}
+	if speed == 0 || speed <= float32(c.Dp(min)) {
+		return false
+	}
+	max := f.MaxVelocity
+	if max == 0 {
+		max = defaultMaxFlingVelocity
+	}
+	if m := float32(c.Dp(max)); speed > m {
+		velocity = velocity.Mul(m / speed)
+		speed = m
+	}
+	k := f.Friction
+	if k <= 0 {
+		k = fling.Friction()
+	}
+	stop := float32(1)
+	if f.StopVelocity > 0 {
+		stop = float32(c.Dp(f.StopVelocity))
+	}
+	if speed <= stop {
+		return false
+	}
+	// The speed v0*e^(-k*t) decays to stop at t = ln(v0/stop)/k.
+	end := math.Log(float64(speed/stop)) / float64(k)
+	f.t0 = now
+	f.v0 = velocity
+	f.k = k
+	f.end = time.Duration(end * float64(time.Second))
+	return true
+}
+
+// Stop the fling.
+func (f *Fling) Stop() {
+	f.v0 = f32.Point{}
+	f.x = f32.Point{}
+}
+
+// Active reports whether the fling is moving.
+func (f *Fling) Active() bool {
+	return f.v0 != (f32.Point{})
+}
+
+// Distance returns the total distance covered by the fling from its
+// start until it comes to rest.
+func (f *Fling) Distance() f32.Point {
+	if !f.Active() {
+		return f32.Point{}
+	}
+	return f.position(f.end)
+}
+
+// Tick returns the distance the fling has moved since the last call
+// to Tick. The fling stops when its speed falls below StopVelocity;
+// call Active to determine whether to keep animating.
+func (f *Fling) Tick(now time.Time) f32.Point {
+	if !f.Active() {
+		return f32.Point{}
+	}
+	t := now.Sub(f.t0)
+	if t < 0 {
+		t = 0
+	}
+	done := t >= f.end
+	if done {
+		t = f.end
+	}
+	x := f.position(t)
+	d := x.Sub(f.x)
+	f.x = x
+	if done {
+		f.Stop()
+	}
+	return d
+}
+
+// position returns the distance covered at time t after the start.
+func (f *Fling) position(t time.Duration) f32.Point {
+	// The speed of a point mass with a drag force proportional to its
+	// velocity decays exponentially, and its position is
+	//
+	// x(t) = v0*(1 - e^(-k*t))/k
+	//
+	// See also internal/fling.Animation.
+	s := (1 - float32(math.Exp(-float64(f.k)*t.Seconds()))) / f.k
+	return f.v0.Mul(s)
+}
+
+func length(p f32.Point) float32 {
+	return float32(math.Hypot(float64(p.X), float64(p.Y)))
+}
@@ -70,6 +70,18 @@ func (h *Hover) Update(q event.Queue) bool {
 // Click detects click gestures in the form
 // of ClickEvents.
 type Click struct {
+	// Buttons is the set of mouse buttons that click. If zero, only
+	// the primary button clicks. Touch presses are reported as
+	// presses of the primary button.
+	Buttons pointer.Buttons
+	// LongPressDuration is the time a pointer must be pressed before
+	// the press is reported as a long press. If zero, long presses
+	// are not detected. See LongPress.
+	LongPressDuration time.Duration
+	// LongPressSlop is the distance a pointer may move during a long
+	// press. If zero, a default distance is used.
+	LongPressSlop unit.Dp
+
 	// clickedAt is the timestamp at which
 	// the last click occurred.
 	clickedAt time.Duration
@@ -84,6 +96,20 @@ type Click struct {
 	entered bool
 	// pid is the pointer.ID.
 	pid pointer.ID
+	// button and source are the button and source of the press.
+	button pointer.Buttons
+	source pointer.Source
+	// start is the position of the press, and moved the
+	// largest squared distance from it.
+	start f32.Point
+	moved float32
+	// pressTime is the time of the press, or zero if it has
+	// not yet been seen by LongPress.
+	pressTime time.Time
+	// longPress tracks whether a long press is pending, and
+	// longPressed whether it has been reported.
+	longPress   bool
+	longPressed bool
 }
 
 // ClickEvent represent a click action, either a
@@ -97,6 +123,8 @@ type ClickEvent struct {
 	// NumClicks records successive clicks occurring
 	// within a short duration of each other.
 	NumClicks int
+	// Button is the button of the press.
+	Button pointer.Buttons
 }
 
 type ClickKind uint8
@@ -114,6 +142,11 @@ type Drag struct {
 // scroll distances. Scroll recognizes mouse wheel
 // movements as well as drag and fling touch gestures.
 type Scroll struct {
+	// Smooth enables smooth scrolling of mouse wheel movements. The
+	// distance of a wheel movement is spread over a short animation
+	// instead of being reported all at once.
+	Smooth bool
+
 	dragging  bool
 	axis      Axis
 	estimator fling.Extrapolation
@@ -123,6 +156,10 @@ type Scroll struct {
 	last      int
 	// Leftover scroll.
 	scroll float32
+	// wheel is the remaining distance of smooth wheel scrolling,
+	// and wheelTime the time it was last reduced.
+	wheel     float32
+	wheelTime time.Time
 }
 
 type ScrollState uint8
@@ -145,6 +182,10 @@ const (
 	// KindCancel is reported when the gesture is
 	// cancelled.
 	KindCancel
+	// KindLongPress is reported when a pointer has been
+	// pressed for the LongPressDuration of a Click. The
+	// release of a long press is reported as KindCancel.
+	KindLongPress
 )
 
 const (
@@ -159,11 +200,22 @@ const (
 
 const touchSlop = unit.Dp(3)
 
+// longPressSlop is the default LongPressSlop of Click.
+const longPressSlop = unit.Dp(8)
+
+// wheelSmoothing is the time constant of smooth wheel scrolling: the
+// remaining distance is reduced by a factor of e every wheelSmoothing.
+const wheelSmoothing = 40 * time.Millisecond
+
 // Add the handler to the operation list to receive click events.
 func (c *Click) Add(ops *op.Ops) {
+	kinds := pointer.Press | pointer.Release | pointer.Enter | pointer.Leave
+	if c.LongPressDuration > 0 {
+		kinds |= pointer.Drag
+	}
 	pointer.InputOp{
 		Tag:   c,
-		Kinds: pointer.Press | pointer.Release | pointer.Enter | pointer.Leave,
+		Kinds: kinds,
 	}.Add(ops)
 }
 
@@ -191,8 +243,12 @@ func (c *Click) Update(q event.Queue) []ClickEvent {
 				break
 			}
 			c.pressed = false
-			if !c.entered || c.hovered {
-				events = append(events, ClickEvent{Kind: KindClick, Position: e.Position.Round(), Source: e.Source, Modifiers: e.Modifiers, NumClicks: c.clicks})
+			c.longPress = false
+			if c.longPressed {
+				c.longPressed = false
+				events = append(events, ClickEvent{Kind: KindCancel})
+			} else if !c.entered || c.hovered {
+				events = append(events, ClickEvent{Kind: KindClick, Position: e.Position.Round(), Source: e.Source, Modifiers: e.Modifiers, NumClicks: c.clicks, Button: c.button})
 			} else {
 				events = append(events, ClickEvent{Kind: KindCancel})
 			}
@@ -201,6 +257,8 @@ func (c *Click) Update(q event.Queue) []ClickEvent {
 			c.pressed = false
 			c.hovered = false
 			c.entered = false
+			c.longPress = false
+			c.longPressed = false
 			if wasPressed {
 				events = append(events, ClickEvent{Kind: KindCancel})
 			}
@@ -208,8 +266,16 @@ func (c *Click) Update(q event.Queue) []ClickEvent {
 			if c.pressed {
 				break
 			}
-			if e.Source == pointer.Mouse && e.Buttons != pointer.ButtonPrimary {
-				break
+			button := pointer.ButtonPrimary
+			if e.Source == pointer.Mouse {
+				buttons := c.Buttons
+				if buttons == 0 {
+					buttons = pointer.ButtonPrimary
+				}
+				if e.Buttons == 0 || !buttons.Contain(e.Buttons) {
+					break
+				}
+				button = e.Buttons
 			}
 			if !c.hovered {
 				c.pid = e.PointerID
@@ -218,13 +284,28 @@ func (c *Click) Update(q event.Queue) []ClickEvent {
 				break
 			}
 			c.pressed = true
+			c.button = button
+			c.source = e.Source
+			c.start = e.Position
+			c.moved = 0
+			c.pressTime = time.Time{}
+			c.longPress = c.LongPressDuration > 0
+			c.longPressed = false
 			if e.Time-c.clickedAt < doubleClickDuration {
 				c.clicks++
 			} else {
 				c.clicks = 1
 			}
 			c.clickedAt = e.Time
-			events = append(events, ClickEvent{Kind: KindPress, Position: e.Position.Round(), Source: e.Source, Modifiers: e.Modifiers, NumClicks: c.clicks})
+			events = append(events, ClickEvent{Kind: KindPress, Position: e.Position.Round(), Source: e.Source, Modifiers: e.Modifiers, NumClicks: c.clicks, Button: button})
+		case pointer.Drag:
+			if !c.pressed || c.pid != e.PointerID {
+				break
+			}
+			d := e.Position.Sub(c.start)
+			if m := d.X*d.X + d.Y*d.Y; m > c.moved {
+				c.moved = m
+			}
 		case pointer.Leave:
 			if !c.pressed {
 				c.pid = e.PointerID
@@ -245,6 +326,42 @@ func (c *Click) Update(q event.Queue) []ClickEvent {
 	return events
 }
 
+// LongPress reports a long press of the current press, if it has lasted
+// for LongPressDuration at time now without moving farther than
+// LongPressSlop. While a long press is pending, LongPress adds an
+// InvalidateOp to ops for the time it is due. Call LongPress after
+// Update for every frame.
+func (c *Click) LongPress(cfg unit.Metric, ops *op.Ops, now time.Time) (ClickEvent, bool) {
+	if !c.pressed || !c.longPress {
+		return ClickEvent{}, false
+	}
+	slop := c.LongPressSlop
+	if slop == 0 {
+		slop = longPressSlop
+	}
+	if s := float32(cfg.Dp(slop)); c.moved > s*s {
+		c.longPress = false
+		return ClickEvent{}, false
+	}
+	if c.pressTime.IsZero() {
+		c.pressTime = now
+	}
+	due := c.pressTime.Add(c.LongPressDuration)
+	if now.Before(due) {
+		op.InvalidateOp{At: due}.Add(ops)
+		return ClickEvent{}, false
+	}
+	c.longPress = false
+	c.longPressed = true
+	return ClickEvent{
+		Kind:      KindLongPress,
+		Position:  c.start.Round(),
+		Source:    c.source,
+		NumClicks: c.clicks,
+		Button:    c.button,
+	}, true
+}
+
 func (ClickEvent) ImplementsEvent() {}
 
 // Add the handler to the operation list to receive scroll events.
@@ -258,14 +375,15 @@ func (s *Scroll) Add(ops *op.Ops, bounds image.Rectangle) {
 		ScrollBounds: bounds,
 	}
 	oph.Add(ops)
-	if s.flinger.Active() {
+	if s.flinger.Active() || s.wheel != 0 {
 		op.InvalidateOp{}.Add(ops)
 	}
 }
 
-// Stop any remaining fling movement.
+// Stop any remaining fling or smooth scrolling movement.
 func (s *Scroll) Stop() {
 	s.flinger = fling.Animation{}
+	s.wheel = 0
 }
 
 // Update state and report the scroll distance along axis.
@@ -310,12 +428,21 @@ func (s *Scroll) Update(cfg unit.Metric, q event.Queue, t time.Time, axis Axis)
 			s.dragging = false
 			s.grab = false
 		case pointer.Scroll:
+			var d float32
 			switch s.axis {
 			case Horizontal:
-				s.scroll += e.Scroll.X
+				d = e.Scroll.X
 			case Vertical:
-				s.scroll += e.Scroll.Y
+				d = e.Scroll.Y
 			}
+			if s.Smooth {
+				if s.wheel == 0 {
+					s.wheelTime = time.Time{}
+				}
+				s.wheel += d
+				break
+			}
+			s.scroll += d
 			iscroll := int(s.scroll)
 			s.scroll -= float32(iscroll)
 			total += iscroll
@@ -339,9 +466,33 @@ func (s *Scroll) Update(cfg unit.Metric, q event.Queue, t time.Time, axis Axis)
 		}
 	}
 	total += s.flinger.Tick(t)
+	total += s.tickWheel(t)
 	return total
 }
 
+// tickWheel computes and returns the smooth wheel scrolling distance
+// since the last call to tickWheel.
+func (s *Scroll) tickWheel(t time.Time) int {
+	if s.wheel == 0 {
+		return 0
+	}
+	dt := t.Sub(s.wheelTime)
+	if s.wheelTime.IsZero() || dt > 100*time.Millisecond || dt < 0 {
+		// Scroll the first frame immediately.
+		dt = 16 * time.Millisecond
+	}
+	s.wheelTime = t
+	rem := s.wheel * float32(math.Exp(-float64(dt)/float64(wheelSmoothing)))
+	if -1 < rem && rem < 1 {
+		rem = 0
+	}
+	dist := s.wheel - rem + s.scroll
+	idist := int(dist)
+	s.scroll = dist - float32(idist)
+	s.wheel = rem
+	return idist
+}
+
 func (s *Scroll) val(p f32.Point) float32 {
 	if s.axis == Horizontal {
 		return p.X
@@ -451,6 +602,8 @@ func (ct ClickKind) String() string {
 		return "KindClick"
 	case KindCancel:
 		return "KindCancel"
+	case KindLongPress:
+		return "KindLongPress"
 	default:
 		panic("invalid ClickKind")
 	}
@@ -13,6 +13,7 @@ import (
 	"gioui.org/io/router"
 	"gioui.org/op"
 	"gioui.org/op/clip"
+	"gioui.org/unit"
 )
 
 func TestHover(t *testing.T) {
@@ -116,3 +117,275 @@ func filterMouseClicks(events []ClickEvent) []ClickEvent {
 	}
 	return clicks
 }
+
+func TestSmoothWheel(t *testing.T) {
+	var s Scroll
+	s.Smooth = true
+	var ops op.Ops
+	stack := clip.Rect(image.Rect(0, 0, 100, 100)).Push(&ops)
+	s.Add(&ops, image.Rect(0, -1000, 0, 1000))
+	stack.Pop()
+	var r router.Router
+	r.Frame(&ops)
+	now := time.Now()
+	// Set the axis.
+	s.Update(unit.Metric{}, &r, now, Vertical)
+	r.Queue(pointer.Event{
+		Kind:     pointer.Scroll,
+		Source:   pointer.Mouse,
+		Position: f32.Pt(50, 50),
+		Scroll:   f32.Pt(0, 100),
+	})
+	total := 0
+	frames := 0
+	for ; frames < 100; frames++ {
+		d := s.Update(unit.Metric{}, &r, now, Vertical)
+		if d < 0 || d >= 100 {
+			t.Fatalf("frame %d: scrolled %d, expected a fraction of the wheel distance", frames, d)
+		}
+		total += d
+		if total == 100 {
+			break
+		}
+		now = now.Add(16 * time.Millisecond)
+	}
+	if total != 100 {
+		t.Errorf("smooth scrolled %d, want %d", total, 100)
+	}
+	if frames < 2 {
+		t.Errorf("smooth scroll completed in %d frames", frames)
+	}
+}
+
+func TestFling(t *testing.T) {
+	var vel Velocity
+	for i := 0; i < 10; i++ {
+		d := time.Duration(i) * 10 * time.Millisecond
+		vel.Sample(d, f32.Pt(float32(i)*10, float32(i)*-5))
+	}
+	v := vel.Velocity()
+	if v.X < 900 || v.X > 1100 || v.Y > -450 || v.Y < -550 {
+		t.Fatalf("velocity %v, want about (1000, -500)", v)
+	}
+
+	var f Fling
+	now := time.Now()
+	if f.Start(unit.Metric{PxPerDp: 1}, now, f32.Pt(10, 0)) {
+		t.Error("fling started below the minimum velocity")
+	}
+	f.Friction = 4
+	f.MaxVelocity = 500
+	if !f.Start(unit.Metric{PxPerDp: 1}, now, v) {
+		t.Fatal("fling didn't start")
+	}
+	dist := f.Distance()
+	var total f32.Point
+	for i := 1; f.Active(); i++ {
+		total = total.Add(f.Tick(now.Add(time.Duration(i) * 16 * time.Millisecond)))
+	}
+	if d := total.Sub(dist); d.X*d.X+d.Y*d.Y > 1e-3 {
+		t.Errorf("fling moved %v, want %v", total, dist)
+	}
+	// The speed is limited to 500 and decays to 1 at a rate of 4/s.
+	if l := length(dist); l < 124 || l > 125 {
+		t.Errorf("fling distance %v, want about 124.75", l)
+	}
+	if dist.X <= 0 || dist.Y >= 0 {
+		t.Errorf("fling direction %v, want the direction of %v", dist, v)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	ops := new(op.Ops)
+	var tr Transform
+	stack := clip.Rect(image.Rect(0, 0, 200, 200)).Push(ops)
+	tr.Add(ops)
+	stack.Pop()
+	r := new(router.Router)
+	r.Frame(ops)
+
+	touch := func(kind pointer.Kind, id pointer.ID, pos f32.Point) pointer.Event {
+		return pointer.Event{Kind: kind, Source: pointer.Touch, PointerID: id, Position: pos}
+	}
+	r.Queue(
+		touch(pointer.Press, 0, f32.Pt(90, 100)),
+		touch(pointer.Press, 1, f32.Pt(110, 100)),
+		// Rotate the second pointer a quarter turn clockwise and
+		// double the distance.
+		touch(pointer.Move, 1, f32.Pt(90, 140)),
+	)
+	a := f32.Affine2D{}
+	for _, e := range tr.Update(unit.Metric{PxPerDp: 1}, r) {
+		a = e.Affine().Mul(a)
+	}
+	for _, p := range [][2]f32.Point{
+		{f32.Pt(90, 100), f32.Pt(90, 100)},
+		{f32.Pt(110, 100), f32.Pt(90, 140)},
+	} {
+		got := a.Transform(p[0])
+		if d := got.Sub(p[1]); d.X*d.X+d.Y*d.Y > 1e-3 {
+			t.Errorf("transform of %v = %v, want %v", p[0], got, p[1])
+		}
+	}
+	if !tr.Transforming() {
+		t.Error("not transforming during gesture")
+	}
+	r.Queue(
+		touch(pointer.Release, 0, f32.Pt(90, 100)),
+		touch(pointer.Release, 1, f32.Pt(90, 140)),
+	)
+	tr.Update(unit.Metric{PxPerDp: 1}, r)
+	if tr.Transforming() {
+		t.Error("transforming after release")
+	}
+}
+
+func TestLongPress(t *testing.T) {
+	click := Click{LongPressDuration: 500 * time.Millisecond}
+	ops := new(op.Ops)
+	stack := clip.Rect(image.Rect(0, 0, 100, 100)).Push(ops)
+	click.Add(ops)
+	stack.Pop()
+	var r router.Router
+	r.Frame(ops)
+	r.Queue(pointer.Event{Kind: pointer.Press, Source: pointer.Touch, Position: f32.Pt(50, 50)})
+	click.Update(&r)
+
+	cfg := unit.Metric{PxPerDp: 1}
+	now := time.Now()
+	ops.Reset()
+	if _, ok := click.LongPress(cfg, ops, now); ok {
+		t.Fatal("long press reported immediately")
+	}
+	if _, ok := click.LongPress(cfg, ops, now.Add(499*time.Millisecond)); ok {
+		t.Fatal("long press reported early")
+	}
+	e, ok := click.LongPress(cfg, ops, now.Add(500*time.Millisecond))
+	if !ok || e.Kind != KindLongPress || e.Button != pointer.ButtonPrimary {
+		t.Fatalf("got long press %v, %v, want a primary button long press", e, ok)
+	}
+	r.Queue(pointer.Event{Kind: pointer.Release, Source: pointer.Touch, Position: f32.Pt(50, 50)})
+	if evts := click.Update(&r); len(evts) != 1 || evts[0].Kind != KindCancel {
+		t.Errorf("got %v after long press, want a KindCancel", evts)
+	}
+
+	// Moving beyond the slop prevents the long press.
+	r.Queue(
+		pointer.Event{Kind: pointer.Press, Source: pointer.Touch, Position: f32.Pt(50, 50)},
+		pointer.Event{Kind: pointer.Move, Source: pointer.Touch, Position: f32.Pt(50, 70)},
+	)
+	click.Update(&r)
+	click.LongPress(cfg, ops, now)
+	if _, ok := click.LongPress(cfg, ops, now.Add(time.Second)); ok {
+		t.Error("long press reported after moving")
+	}
+}
+
+func TestSecondaryClick(t *testing.T) {
+	click := Click{Buttons: pointer.ButtonPrimary | pointer.ButtonSecondary}
+	ops := new(op.Ops)
+	stack := clip.Rect(image.Rect(0, 0, 100, 100)).Push(ops)
+	click.Add(ops)
+	stack.Pop()
+	var r router.Router
+	r.Frame(ops)
+	r.Queue(
+		pointer.Event{Kind: pointer.Press, Source: pointer.Mouse, Buttons: pointer.ButtonSecondary},
+		pointer.Event{Kind: pointer.Release, Source: pointer.Mouse},
+		pointer.Event{Kind: pointer.Press, Source: pointer.Mouse, Buttons: pointer.ButtonTertiary},
+		pointer.Event{Kind: pointer.Release, Source: pointer.Mouse},
+	)
+	clicks := filterMouseClicks(click.Update(&r))
+	if len(clicks) != 1 || clicks[0].Button != pointer.ButtonSecondary {
+		t.Errorf("got clicks %v, want one secondary click", clicks)
+	}
+}
+
+func TestPan(t *testing.T) {
+	ops := new(op.Ops)
+	var p Pan
+	stack := clip.Rect(image.Rect(0, 0, 200, 200)).Push(ops)
+	p.Add(ops)
+	stack.Pop()
+	var r router.Router
+	r.Frame(ops)
+
+	touch := func(kind pointer.Kind, ms int, pos f32.Point) pointer.Event {
+		return pointer.Event{Kind: kind, Source: pointer.Touch, Time: time.Duration(ms) * time.Millisecond, Position: pos}
+	}
+	r.Queue(
+		touch(pointer.Press, 0, f32.Pt(100, 100)),
+		// Within the slop.
+		touch(pointer.Move, 10, f32.Pt(101, 101)),
+	)
+	for i := 2; i <= 10; i++ {
+		r.Queue(touch(pointer.Move, i*10, f32.Pt(100+float32(i)*5, 100-float32(i)*5)))
+	}
+	r.Queue(touch(pointer.Release, 110, f32.Pt(155, 45)))
+	events := p.Update(unit.Metric{PxPerDp: 1}, &r)
+	if len(events) != 10 {
+		t.Fatalf("got %d events, want 10", len(events))
+	}
+	var total f32.Point
+	for i, e := range events {
+		want := PanMove
+		switch i {
+		case 0:
+			want = PanStart
+		case len(events) - 1:
+			want = PanEnd
+		}
+		if e.Kind != want {
+			t.Errorf("event %d is %v, want %v", i, e.Kind, want)
+		}
+		total = total.Add(e.Delta)
+	}
+	if total != f32.Pt(55, -55) {
+		t.Errorf("panned %v, want (55, -55)", total)
+	}
+	if v := events[len(events)-1].Velocity; v.X < 400 || v.Y > -400 {
+		t.Errorf("release velocity %v, want about (500, -500)", v)
+	}
+	if p.Panning() {
+		t.Error("panning after release")
+	}
+}
+
+func TestPanCancel(t *testing.T) {
+	ops := new(op.Ops)
+	var p Pan
+	stack := clip.Rect(image.Rect(0, 0, 200, 200)).Push(ops)
+	p.Add(ops)
+	stack.Pop()
+	var r router.Router
+	r.Frame(ops)
+
+	touch := func(kind pointer.Kind, pos f32.Point) pointer.Event {
+		return pointer.Event{Kind: kind, Source: pointer.Touch, PointerID: 1, Position: pos}
+	}
+	r.Queue(
+		touch(pointer.Press, f32.Pt(100, 100)),
+		touch(pointer.Move, f32.Pt(150, 100)),
+	)
+	p.Update(unit.Metric{PxPerDp: 1}, &r)
+	if !p.Panning() {
+		t.Fatal("not panning after a drag")
+	}
+	r.Queue(pointer.Event{Kind: pointer.Cancel})
+	events := p.Update(unit.Metric{PxPerDp: 1}, &r)
+	if len(events) != 1 || events[0].Kind != PanCancel {
+		t.Errorf("got events %v after a cancel, want a PanCancel", events)
+	}
+	if p.Panning() {
+		t.Error("panning after a cancel")
+	}
+	// A new pan starts after the cancel.
+	r.Queue(
+		touch(pointer.Press, f32.Pt(100, 100)),
+		touch(pointer.Move, f32.Pt(100, 150)),
+	)
+	events = p.Update(unit.Metric{PxPerDp: 1}, &r)
+	if len(events) != 1 || events[0].Kind != PanStart {
+		t.Errorf("got events %v after a new drag, want a PanStart", events)
+	}
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package gesture
+
+import (
+	"gioui.org/f32"
+	"gioui.org/io/event"
+	"gioui.org/io/pointer"
+	"gioui.org/op"
+	"gioui.org/unit"
+)
+
+// Pan detects 2-dimensional pan gestures of a touch or primary button
+// mouse pointer, such as for moving the view of a canvas. A pan starts
+// when the pointer has moved farther than the touch slop.
+type Pan struct {
+	pid      pointer.ID
+	pressed  bool
+	panning  bool
+	grab     bool
+	start    f32.Point
+	last     f32.Point
+	velocity Velocity
+}
+
+// PanEvent is a change of a pan gesture.
+type PanEvent struct {
+	Kind PanKind
+	// Position is the position of the pointer.
+	Position f32.Point
+	// Delta is the movement of the pointer since the previous event.
+	// The Delta of the PanStart event includes the movement within the
+	// touch slop.
+	Delta f32.Point
+	// Velocity is the estimated velocity of the pointer, in pixels
+	// per second, when it was released. It is only set for PanEnd
+	// events and is suitable for starting a Fling.
+	Velocity f32.Point
+}
+
+type PanKind uint8
+
+const (
+	// PanStart is reported for the first movement of a pan.
+	PanStart PanKind = iota
+	// PanMove is reported for subsequent movements.
+	PanMove
+	// PanEnd is reported when the pointer is released.
+	PanEnd
+	// PanCancel is reported when the pan is cancelled.
+	PanCancel
+)
+
+// Add the handler to the operation list to receive pan events.
+func (p *Pan) Add(ops *op.Ops) {
+	pointer.InputOp{
+		Tag:   p,
+		Grab:  p.grab,
+		Kinds: pointer.Press | pointer.Drag | pointer.Release | pointer.Cancel,
+	}.Add(ops)
+}
+
+// Panning reports whether a pan is in progress.
+func (p *Pan) Panning() bool {
+	return p.panning
+}
+
+// Update state and return the pan events.
+func (p *Pan) Update(cfg unit.Metric, q event.Queue) []PanEvent {
+	var events []PanEvent
+	for _, e := range q.Events(p) {
+		e, ok := e.(pointer.Event)
+		if !ok {
+			continue
+		}
+		switch e.Kind {
+		case pointer.Press:
+			if p.pressed || !(e.Buttons == pointer.ButtonPrimary || e.Source == pointer.Touch) {
+				continue
+			}
+			p.pressed = true
+			p.pid = e.PointerID
+			p.start, p.last = e.Position, e.Position
+			p.velocity.Reset()
+			p.velocity.Sample(e.Time, e.Position)
+		case pointer.Drag:
+			if !p.pressed || e.PointerID != p.pid {
+				continue
+			}
+			p.velocity.Sample(e.Time, e.Position)
+			kind := PanMove
+			if !p.panning {
+				diff := e.Position.Sub(p.start)
+				slop := float32(cfg.Dp(touchSlop))
+				if diff.X*diff.X+diff.Y*diff.Y <= slop*slop {
+					continue
+				}
+				p.panning = true
+				p.grab = true
+				kind = PanStart
+			}
+			events = append(events, PanEvent{Kind: kind, Position: e.Position, Delta: e.Position.Sub(p.last)})
+			p.last = e.Position
+		case pointer.Release:
+			if !p.pressed || e.PointerID != p.pid {
+				continue
+			}
+			if p.panning {
+				events = append(events, PanEvent{Kind: PanEnd, Position: e.Position, Delta: e.Position.Sub(p.last), Velocity: p.velocity.Velocity()})
+			}
+			p.pressed = false
+			p.panning = false
+			p.grab = false
+		case pointer.Cancel:
+			// Cancel events don't carry the pointer of the gesture.
+			if p.panning {
+				events = append(events, PanEvent{Kind: PanCancel, Position: p.last})
+			}
+			p.pressed = false
+			p.panning = false
+			p.grab = false
+		}
+	}
+	return events
+}
+
+func (k PanKind) String() string {
+	switch k {
+	case PanStart:
+		return "PanStart"
+	case PanMove:
+		return "PanMove"
+	case PanEnd:
+		return "PanEnd"
+	case PanCancel:
+		return "PanCancel"
+	default:
+		panic("invalid PanKind")
+	}
+}
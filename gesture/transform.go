@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package gesture
+
+import (
+	"image"
+	"math"
+
+	"gioui.org/f32"
+	"gioui.org/io/event"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/op"
+	"gioui.org/unit"
+)
+
+// Transform detects pinch, zoom and rotate gestures of two or more
+// touch pointers, and pans of a single touch or mouse pointer. It
+// also reports scroll movements as pans and scroll movements with
+// the control key held as zooms, the form trackpad pinches take on
+// platforms such as browsers.
+type Transform struct {
+	pointers []transformPointer
+	grab     bool
+}
+
+type transformPointer struct {
+	id    pointer.ID
+	start f32.Point
+	pos   f32.Point
+}
+
+// TransformEvent is an incremental transformation: a scale and a
+// rotation around Origin, followed by a translation by Offset.
+type TransformEvent struct {
+	// Origin is the center of the scale and rotation, the centroid
+	// of the pointers before the change.
+	Origin f32.Point
+	// Offset is the translation.
+	Offset f32.Point
+	// Scale is the scale factor.
+	Scale float32
+	// Rotation is the rotation in radians, clockwise in the
+	// coordinate system of the pointer events.
+	Rotation float32
+}
+
+// zoomScrollScale is the scroll distance, in dp, that zooms by a
+// factor of e.
+const zoomScrollScale = unit.Dp(100)
+
+// Add the gesture to detect transformations over the current pointer
+// area.
+func (t *Transform) Add(ops *op.Ops) {
+	const inf = 1e6
+	pointer.InputOp{
+		Tag:          t,
+		Grab:         t.grab,
+		Kinds:        pointer.Press | pointer.Drag | pointer.Release | pointer.Cancel | pointer.Scroll,
+		ScrollBounds: image.Rect(-inf, -inf, inf, inf),
+	}.Add(ops)
+}
+
+// Transforming reports whether any pointer is down.
+func (t *Transform) Transforming() bool {
+	return len(t.pointers) > 0
+}
+
+// Update state and return the transformations since the last call.
+func (t *Transform) Update(cfg unit.Metric, q event.Queue) []TransformEvent {
+	var events []TransformEvent
+	for _, e := range q.Events(t) {
+		e, ok := e.(pointer.Event)
+		if !ok {
+			continue
+		}
+		switch e.Kind {
+		case pointer.Press:
+			if !(e.Buttons == pointer.ButtonPrimary || e.Source == pointer.Touch) {
+				continue
+			}
+			if t.index(e.PointerID) != -1 {
+				continue
+			}
+			t.pointers = append(t.pointers, transformPointer{id: e.PointerID, start: e.Position, pos: e.Position})
+			if len(t.pointers) > 1 {
+				t.grab = true
+			}
+		case pointer.Drag:
+			i := t.index(e.PointerID)
+			if i == -1 {
+				continue
+			}
+			p := &t.pointers[i]
+			if e.Priority < pointer.Grabbed {
+				diff := e.Position.Sub(p.start)
+				slop := float32(cfg.Dp(touchSlop))
+				if diff.X*diff.X+diff.Y*diff.Y > slop*slop {
+					t.grab = true
+				}
+			}
+			if ev, ok := t.move(i, e.Position); ok {
+				events = append(events, ev)
+			}
+		case pointer.Release, pointer.Cancel:
+			if i := t.index(e.PointerID); i != -1 {
+				t.pointers = append(t.pointers[:i], t.pointers[i+1:]...)
+			}
+			if len(t.pointers) == 0 || e.Kind == pointer.Cancel {
+				t.pointers = t.pointers[:0]
+				t.grab = false
+			}
+		case pointer.Scroll:
+			if e.Scroll == (f32.Point{}) {
+				continue
+			}
+			ev := TransformEvent{Origin: e.Position, Scale: 1}
+			if e.Modifiers.Contain(key.ModCtrl) {
+				s := e.Scroll.X + e.Scroll.Y
+				ev.Scale = float32(math.Exp(-float64(s) / float64(cfg.Dp(zoomScrollScale))))
+			} else {
+				ev.Offset = e.Scroll.Mul(-1)
+			}
+			events = append(events, ev)
+		}
+	}
+	return events
+}
+
+// move pointer i to pos and returns the resulting transformation.
+func (t *Transform) move(i int, pos f32.Point) (TransformEvent, bool) {
+	old := t.pointers[i].pos
+	if old == pos {
+		return TransformEvent{}, false
+	}
+	c0 := t.centroid()
+	t.pointers[i].pos = pos
+	c1 := t.centroid()
+	ev := TransformEvent{Origin: c0, Offset: c1.Sub(c0), Scale: 1}
+	if len(t.pointers) < 2 {
+		return ev, true
+	}
+	var d0, d1, rot float32
+	for j, p := range t.pointers {
+		from := p.pos
+		if j == i {
+			from = old
+		}
+		v0, v1 := from.Sub(c0), p.pos.Sub(c1)
+		d0 += length(v0)
+		d1 += length(v1)
+		a := math.Atan2(float64(v1.Y), float64(v1.X)) - math.Atan2(float64(v0.Y), float64(v0.X))
+		// Wrap the angle to [-π, π].
+		a = math.Remainder(a, 2*math.Pi)
+		rot += float32(a)
+	}
+	if d0 > 0 && d1 > 0 {
+		ev.Scale = d1 / d0
+	}
+	ev.Rotation = rot / float32(len(t.pointers))
+	return ev, true
+}
+
+func (t *Transform) centroid() f32.Point {
+	var c f32.Point
+	for _, p := range t.pointers {
+		c = c.Add(p.pos)
+	}
+	return c.Div(float32(len(t.pointers)))
+}
+
+func (t *Transform) index(id pointer.ID) int {
+	for i, p := range t.pointers {
+		if p.id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// Affine returns the transformation as an affine transformation.
+func (e TransformEvent) Affine() f32.Affine2D {
+	return f32.Affine2D{}.
+		Scale(e.Origin, f32.Pt(e.Scale, e.Scale)).
+		Rotate(e.Origin, e.Rotation).
+		Offset(e.Offset)
+}
@@ -168,6 +168,35 @@ func Hovered(c color.NRGBA) (h color.NRGBA) {
 	return mix(m, c, ratio)
 }
 
+// Lerp interpolates linearly between c1 and c2 by t in [0,1]. The
+// interpolation is done with premultiplied alpha, so that fading from
+// a transparent color doesn't darken.
+func Lerp(c1, c2 color.NRGBA, t float32) color.NRGBA {
+	if t <= 0 {
+		return c1
+	}
+	if t >= 1 {
+		return c2
+	}
+	lerp := func(v1, v2 float32) float32 {
+		return v1 + (v2-v1)*t
+	}
+	a1, a2 := float32(c1.A), float32(c2.A)
+	a := lerp(a1, a2)
+	if a == 0 {
+		return color.NRGBA{}
+	}
+	ch := func(v1, v2 uint8) uint8 {
+		return uint8(lerp(float32(v1)*a1, float32(v2)*a2)/a + .5)
+	}
+	return color.NRGBA{
+		R: ch(c1.R, c2.R),
+		G: ch(c1.G, c2.G),
+		B: ch(c1.B, c2.B),
+		A: uint8(a + .5),
+	}
+}
+
 // mix mixes c1 and c2 weighted by (1 - a/256) and a/256 respectively.
 func mix(c1, c2 color.NRGBA, a uint8) color.NRGBA {
 	ai := int(a)
@@ -56,3 +56,15 @@ func BenchmarkLinearFromSRGB(b *testing.B) {
 		}
 	})
 }
+
+func TestLerp(t *testing.T) {
+	red := color.NRGBA{R: 0xff, A: 0xff}
+	blue := color.NRGBA{B: 0xff, A: 0xff}
+	if got, want := Lerp(red, blue, .5), (color.NRGBA{R: 0x80, B: 0x80, A: 0xff}); got != want {
+		t.Errorf("Lerp(red, blue, .5) = %v, want %v", got, want)
+	}
+	// Fading in from transparent keeps the color.
+	if got, want := Lerp(color.NRGBA{}, red, .5), (color.NRGBA{R: 0xff, A: 0x80}); got != want {
+		t.Errorf("Lerp(transparent, red, .5) = %v, want %v", got, want)
+	}
+}
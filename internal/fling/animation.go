@@ -54,18 +54,34 @@ func (f *Animation) Active() bool {
 	return f.v0 != 0
 }
 
+// Distance returns the total distance covered by the active
+// fling when it comes to rest.
+func (f *Animation) Distance() float32 {
+	// The limit of x(t) as t grows, see Tick.
+	return -f.v0 / decay()
+}
+
+// Friction returns the platform default friction of flings, the
+// rate at which their velocity decays per second.
+func Friction() float32 {
+	return -decay()
+}
+
+// decay returns the drag coefficient of flings.
+func decay() float32 {
+	if runtime.GOOS == "darwin" {
+		return -2 // iOS
+	}
+	return -4.2 // Android and default
+}
+
 // Tick computes and returns a fling distance since
 // the last time Tick was called.
 func (f *Animation) Tick(now time.Time) int {
 	if !f.Active() {
 		return 0
 	}
-	var k float32
-	if runtime.GOOS == "darwin" {
-		k = -2 // iOS
-	} else {
-		k = -4.2 // Android and default
-	}
+	k := decay()
 	t := now.Sub(f.t0)
 	// The acceleration x''(t) of a point mass with a drag
 	// force, f, proportional with velocity, x'(t), is
@@ -88,6 +88,7 @@ const (
 	TypeSnippet
 	TypeSelection
 	TypeActionInput
+	TypeKeyFocusTrap
 )
 
 type StackID struct {
@@ -174,6 +175,7 @@ const (
 	TypeSnippetLen          = 1 + 4 + 4
 	TypeSelectionLen        = 1 + 2*4 + 2*4 + 4 + 4
 	TypeActionInputLen      = 1 + 1
+	TypeKeyFocusTrapLen     = 1
 )
 
 func (op *ClipOp) Decode(data []byte) {
@@ -458,6 +460,7 @@ var opProps = [0x100]opProp{
 	TypeSnippet:          {Size: TypeSnippetLen, NumRefs: 2},
 	TypeSelection:        {Size: TypeSelectionLen, NumRefs: 1},
 	TypeActionInput:      {Size: TypeActionInputLen, NumRefs: 0},
+	TypeKeyFocusTrap:     {Size: TypeKeyFocusTrapLen, NumRefs: 0},
 }
 
 func (t OpType) props() (size, numRefs uint32) {
@@ -521,6 +524,8 @@ func (t OpType) String() string {
 		return "KeyFocus"
 	case TypeKeySoftKeyboard:
 		return "KeySoftKeyboard"
+	case TypeKeyFocusTrap:
+		return "KeyFocusTrap"
 	case TypeSave:
 		return "Save"
 	case TypeLoad:
@@ -65,6 +65,13 @@ type FocusOp struct {
 	Tag event.Tag
 }
 
+// FocusTrapOp confines the keyboard focus to the handlers whose InputOps
+// follow it in the same frame, such as the handlers of a modal dialog
+// drawn above other content. Focus movement, and key events not
+// accepted by the focused handler, skip the handlers before it. Only
+// the last FocusTrapOp of a frame has effect.
+type FocusTrapOp struct{}
+
 // SelectionOp updates the selection for an input handler.
 type SelectionOp struct {
 	Tag event.Tag
@@ -341,6 +348,11 @@ func (h FocusOp) Add(o *op.Ops) {
 	data[0] = byte(ops.TypeKeyFocus)
 }
 
+func (h FocusTrapOp) Add(o *op.Ops) {
+	data := ops.Write(&o.Internal, ops.TypeKeyFocusTrapLen)
+	data[0] = byte(ops.TypeKeyFocusTrap)
+}
+
 func (s SnippetOp) Add(o *op.Ops) {
 	data := ops.Write2String(&o.Internal, ops.TypeSnippetLen, s.Tag, s.Text)
 	data[0] = byte(ops.TypeSnippet)
@@ -27,6 +27,9 @@ type keyQueue struct {
 	focus    event.Tag
 	order    []event.Tag
 	dirOrder []dirFocusEntry
+	// trap is the index into order of the first handler after the
+	// last FocusTrapOp, or -1.
+	trap     int
 	handlers map[event.Tag]*keyHandler
 	state    TextInputState
 	hint     key.InputHint
@@ -108,6 +111,7 @@ func (q *keyQueue) Reset() {
 	}
 	q.order = q.order[:0]
 	q.dirOrder = q.dirOrder[:0]
+	q.trap = -1
 }
 
 func (q *keyQueue) Frame(events *handlerEvents, collector keyCollector) {
@@ -175,6 +179,20 @@ func (q *keyQueue) MoveFocus(dir FocusDirection, events *handlerEvents) bool {
 	if len(q.dirOrder) == 0 {
 		return false
 	}
+	if q.trap != -1 {
+		if q.trap == len(q.order) {
+			return false
+		}
+		if q.focus == nil || !q.Trapped(q.focus) {
+			// Enter the trap.
+			first := q.trap
+			if dir == FocusBackward {
+				first = len(q.order) - 1
+			}
+			q.setFocus(q.order[first], events)
+			return true
+		}
+	}
 	order := 0
 	if q.focus != nil {
 		order = q.handlers[q.focus].dirOrder
@@ -197,7 +215,12 @@ func (q *keyQueue) MoveFocus(dir FocusDirection, events *handlerEvents) bool {
 				order--
 			}
 		}
-		order = (order + len(q.order)) % len(q.order)
+		start, n := 0, len(q.order)
+		if q.trap != -1 {
+			start = q.trap
+			n -= start
+		}
+		order = start + (order-start+n)%n
 		q.setFocus(q.order[order], events)
 		return true
 	case FocusRight, FocusLeft:
@@ -208,7 +231,7 @@ func (q *keyQueue) MoveFocus(dir FocusDirection, events *handlerEvents) bool {
 				next = order - 1
 			}
 		}
-		if 0 <= next && next < len(q.dirOrder) {
+		if 0 <= next && next < len(q.dirOrder) && q.Trapped(q.dirOrder[next].tag) {
 			newFocus := q.dirOrder[next]
 			if newFocus.row == focus.row {
 				q.setFocus(newFocus.tag, events)
@@ -230,6 +253,10 @@ func (q *keyQueue) MoveFocus(dir FocusDirection, events *handlerEvents) bool {
 	loop:
 		for 0 <= order && order < len(q.dirOrder) {
 			next := q.dirOrder[order]
+			if !q.Trapped(next.tag) {
+				order += delta
+				continue
+			}
 			switch next.row {
 			case nextRow:
 				nextCenter := (next.bounds.Min.X + next.bounds.Max.X) / 2
@@ -255,6 +282,16 @@ func (q *keyQueue) MoveFocus(dir FocusDirection, events *handlerEvents) bool {
 	return false
 }
 
+// Trapped reports whether the handler t follows the last
+// key.FocusTrapOp, or whether there is none.
+func (q *keyQueue) Trapped(t event.Tag) bool {
+	if q.trap == -1 {
+		return true
+	}
+	h, ok := q.handlers[t]
+	return ok && h.order >= q.trap
+}
+
 func (q *keyQueue) BoundsFor(t event.Tag) image.Rectangle {
 	order := q.handlers[t].dirOrder
 	return q.dirOrder[order].bounds
@@ -296,6 +333,10 @@ func (k *keyCollector) focusOp(tag event.Tag) {
 	k.changed = true
 }
 
+func (k *keyCollector) focusTrap() {
+	k.q.trap = len(k.q.order)
+}
+
 func (k *keyCollector) softKeyboard(show bool) {
 	if show {
 		k.q.state = TextInputOpen
@@ -264,6 +264,44 @@ func TestDirectionalFocus(t *testing.T) {
 	assertFocus(t, r, &handlers[0])
 }
 
+func TestFocusTrap(t *testing.T) {
+	ops := new(op.Ops)
+	r := new(Router)
+	handlers := make([]int, 4)
+	for i := range handlers {
+		if i == 2 {
+			key.FocusTrapOp{}.Add(ops)
+		}
+		cl := clip.Rect(image.Rect(i*10, 0, i*10+10, 10)).Push(ops)
+		key.InputOp{Tag: &handlers[i], Keys: "A"}.Add(ops)
+		cl.Pop()
+	}
+	key.FocusOp{Tag: &handlers[0]}.Add(ops)
+	r.Frame(ops)
+
+	// Key events are not delivered outside the trap.
+	r.Queue(key.Event{Name: "A"})
+	for _, e := range r.Events(&handlers[0]) {
+		if _, ok := e.(key.Event); ok {
+			t.Error("key event delivered outside of the trap")
+		}
+	}
+	r.MoveFocus(FocusForward)
+	assertFocus(t, r, &handlers[2])
+	r.MoveFocus(FocusForward)
+	assertFocus(t, r, &handlers[3])
+	r.MoveFocus(FocusForward)
+	assertFocus(t, r, &handlers[2])
+	r.MoveFocus(FocusBackward)
+	assertFocus(t, r, &handlers[3])
+	r.MoveFocus(FocusRight)
+	assertFocus(t, r, &handlers[3])
+	r.MoveFocus(FocusLeft)
+	assertFocus(t, r, &handlers[2])
+	r.MoveFocus(FocusLeft)
+	assertFocus(t, r, &handlers[2])
+}
+
 func TestFocusScroll(t *testing.T) {
 	ops := new(op.Ops)
 	r := new(Router)
@@ -204,6 +204,9 @@ func rangeNorm(r key.Range) key.Range {
 func (q *Router) queueKeyEvent(e key.Event) {
 	kq := &q.key.queue
 	f := q.key.queue.focus
+	if f != nil && !kq.Trapped(f) {
+		f = nil
+	}
 	if f != nil && kq.Accepts(f, e) {
 		q.handlers.Add(f, e)
 		return
@@ -224,7 +227,7 @@ func (q *Router) queueKeyEvent(e key.Event) {
 		} else {
 			idx--
 		}
-		if n.ktag == nil {
+		if n.ktag == nil || !kq.Trapped(n.ktag) {
 			continue
 		}
 		if kq.Accepts(n.ktag, e) {
@@ -489,6 +492,8 @@ func (q *Router) collect() {
 				Show: encOp.Data[1] != 0,
 			}
 			kc.softKeyboard(op.Show)
+		case ops.TypeKeyFocusTrap:
+			kc.focusTrap()
 		case ops.TypeKeyInput:
 			filter := key.Set(*encOp.Refs[1].(*string))
 			op := key.InputOp{
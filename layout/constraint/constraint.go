@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package constraint
+
+import (
+	"gioui.org/unit"
+)
+
+// Var is a variable of a constraint system, such as an edge of a Box.
+type Var struct {
+	// value is the solved value, in pixels.
+	value float32
+}
+
+// Expr is a linear expression: a sum of variables multiplied by
+// coefficients, plus a constant.
+type Expr struct {
+	terms    []term
+	constant unit.Dp
+}
+
+// Linear is implemented by *Var and Expr.
+type Linear interface {
+	Expr() Expr
+}
+
+// Constraint is a linear equation or inequality with a strength.
+type Constraint struct {
+	expr     Expr
+	op       relation
+	strength Strength
+}
+
+// Strength is the priority of a constraint. The solver satisfies
+// required constraints, and then minimizes the errors of
+// non-required constraints weighted by their strengths.
+type Strength float64
+
+type term struct {
+	v     *Var
+	coeff float64
+}
+
+type relation uint8
+
+// constraint is a Constraint with its constant in pixels. It
+// represents the relation expr op 0.
+type constraint struct {
+	terms    []term
+	constant float64
+	op       relation
+	strength Strength
+}
+
+const (
+	opEq relation = iota
+	opLe
+	opGe
+)
+
+const (
+	// Required constraints must be satisfied.
+	Required Strength = 1001001000
+	Strong   Strength = 1000000
+	Medium   Strength = 1000
+	Weak     Strength = 1
+)
+
+// Value returns the value of the variable in the last solution, in
+// pixels.
+func (v *Var) Value() float32 {
+	return v.value
+}
+
+// Expr converts v to an expression.
+func (v *Var) Expr() Expr {
+	return Expr{terms: []term{{v: v, coeff: 1}}}
+}
+
+// Plus returns the expression v + d.
+func (v *Var) Plus(d unit.Dp) Expr {
+	return v.Expr().Plus(d)
+}
+
+// Add returns the expression v + l.
+func (v *Var) Add(l Linear) Expr {
+	return v.Expr().Add(l)
+}
+
+// Sub returns the expression v - l.
+func (v *Var) Sub(l Linear) Expr {
+	return v.Expr().Sub(l)
+}
+
+// Mul returns the expression k * v.
+func (v *Var) Mul(k float32) Expr {
+	return v.Expr().Mul(k)
+}
+
+// Eq returns the constraint v == l.
+func (v *Var) Eq(l Linear) Constraint {
+	return v.Expr().Eq(l)
+}
+
+// Le returns the constraint v <= l.
+func (v *Var) Le(l Linear) Constraint {
+	return v.Expr().Le(l)
+}
+
+// Ge returns the constraint v >= l.
+func (v *Var) Ge(l Linear) Constraint {
+	return v.Expr().Ge(l)
+}
+
+// Const returns the constant expression d.
+func Const(d unit.Dp) Expr {
+	return Expr{constant: d}
+}
+
+// Expr returns e.
+func (e Expr) Expr() Expr {
+	return e
+}
+
+// Plus returns the expression e + d.
+func (e Expr) Plus(d unit.Dp) Expr {
+	e.constant += d
+	return e
+}
+
+// Add returns the expression e + l.
+func (e Expr) Add(l Linear) Expr {
+	o := l.Expr()
+	terms := make([]term, 0, len(e.terms)+len(o.terms))
+	terms = append(terms, e.terms...)
+	terms = append(terms, o.terms...)
+	return Expr{terms: terms, constant: e.constant + o.constant}
+}
+
+// Sub returns the expression e - l.
+func (e Expr) Sub(l Linear) Expr {
+	return e.Add(l.Expr().Mul(-1))
+}
+
+// Mul returns the expression k * e.
+func (e Expr) Mul(k float32) Expr {
+	terms := make([]term, len(e.terms))
+	for i, t := range e.terms {
+		terms[i] = term{v: t.v, coeff: t.coeff * float64(k)}
+	}
+	return Expr{terms: terms, constant: e.constant * unit.Dp(k)}
+}
+
+// Eq returns the constraint e == l.
+func (e Expr) Eq(l Linear) Constraint {
+	return Constraint{expr: e.Sub(l), op: opEq, strength: Required}
+}
+
+// Le returns the constraint e <= l.
+func (e Expr) Le(l Linear) Constraint {
+	return Constraint{expr: e.Sub(l), op: opLe, strength: Required}
+}
+
+// Ge returns the constraint e >= l.
+func (e Expr) Ge(l Linear) Constraint {
+	return Constraint{expr: e.Sub(l), op: opGe, strength: Required}
+}
+
+// Strength returns the constraint with strength s. Constraints are
+// required by default.
+func (c Constraint) Strength(s Strength) Constraint {
+	if s > Required {
+		s = Required
+	}
+	c.strength = s
+	return c
+}
+
+// px converts the constraint to pixels with the scale pxPerDp.
+func (c Constraint) px(pxPerDp float32) constraint {
+	return constraint{
+		terms:    c.expr.terms,
+		constant: float64(c.expr.constant) * float64(pxPerDp),
+		op:       c.op,
+		strength: c.strength,
+	}
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package constraint
+
+import (
+	"image"
+	"testing"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+)
+
+func TestSolver(t *testing.T) {
+	var x, y Var
+	s := newSolver()
+	for _, c := range []Constraint{
+		x.Add(&y).Eq(Const(20)),
+		x.Ge(Const(15)),
+		// Weaker preferences are satisfied as far as possible.
+		x.Eq(Const(5)).Strength(Weak),
+		y.Eq(Const(0)).Strength(Medium),
+	} {
+		if err := s.Add(c.px(1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := s.Value(&x), 20.0; got != want {
+		t.Errorf("x = %v, want %v", got, want)
+	}
+	if got, want := s.Value(&y), 0.0; got != want {
+		t.Errorf("y = %v, want %v", got, want)
+	}
+	if err := s.Add(x.Le(Const(10)).px(1)); err == nil {
+		t.Error("conflicting required constraint was added")
+	}
+}
+
+func TestSolverConflict(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		conflict func(x *Var) Constraint
+	}{
+		{"x <= 5", func(x *Var) Constraint { return x.Le(Const(5)) }},
+		{"x == 5", func(x *Var) Constraint { return x.Eq(Const(5)) }},
+	} {
+		var x Var
+		s := newSolver()
+		if err := s.Add(x.Ge(Const(10)).px(1)); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Add(c.conflict(&x).px(1)); err == nil {
+			t.Errorf("%s: conflicting required constraint was added", c.name)
+		}
+		if got := s.Value(&x); got < 10 {
+			t.Errorf("%s: x = %v after the rejected constraint, want at least 10", c.name, got)
+		}
+		// A weak preference moves x as far as the first constraint
+		// allows.
+		if err := s.Add(x.Eq(Const(0)).Strength(Weak).px(1)); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := s.Value(&x), 10.0; got != want {
+			t.Errorf("%s: x = %v after the rejected constraint, want %v", c.name, got, want)
+		}
+	}
+}
+
+func TestLayout(t *testing.T) {
+	gtx := layout.Context{
+		Ops:         new(op.Ops),
+		Constraints: layout.Constraints{Max: image.Pt(200, 100)},
+	}
+	var sizes []image.Point
+	widget := func(sz image.Point) layout.Widget {
+		return func(gtx layout.Context) layout.Dimensions {
+			sz := gtx.Constraints.Constrain(sz)
+			sizes = append(sizes, sz)
+			return layout.Dimensions{Size: sz}
+		}
+	}
+	var l Layout
+	a := l.Child(widget(image.Pt(30, 10)))
+	b := l.Child(widget(image.Pt(50, 20)))
+	l.Add(
+		a.Left.Eq(l.Left.Plus(10)),
+		b.Left.Eq(a.Right.Plus(5)),
+		a.CenterY.Eq(&b.CenterY),
+		// Stretch a.
+		a.Width.Eq(Const(50)),
+	)
+	dims := l.Layout(gtx)
+	if got, want := dims.Size, image.Pt(115, 20); got != want {
+		t.Errorf("layout size %v, want %v", got, want)
+	}
+	for _, c := range []struct {
+		name      string
+		got, want float32
+	}{
+		{"a.Left", a.Left.Value(), 10},
+		{"a.Width", a.Width.Value(), 50},
+		{"a.Top", a.Top.Value(), 5},
+		{"b.Left", b.Left.Value(), 65},
+	} {
+		if c.got != c.want {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	// a is laid out again with its solved size.
+	if got, want := sizes[len(sizes)-1], image.Pt(50, 10); got != want {
+		t.Errorf("a laid out with size %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+/*
+Package constraint implements a layout where the edges of children are
+determined by a system of linear equations and inequalities, solved
+with the Cassowary algorithm.
+
+Every child has a Box of variables for its edges, size and center.
+Constraints relate the variables of boxes, for example to align the
+labels of two forms:
+
+	var l constraint.Layout
+	name := l.Child(nameLabel)
+	email := l.Child(emailLabel)
+	l.Add(
+		name.Left.Eq(l.Left.Plus(16)),
+		email.Left.Eq(&name.Left),
+		email.Top.Eq(name.Bottom.Plus(8)),
+		// Prefer, but don't require, labels of equal width.
+		email.Width.Eq(&name.Width).Strength(constraint.Medium),
+	)
+	dims := l.Layout(gtx)
+
+Constraints are required unless given a weaker Strength. The solver
+satisfies all required constraints, and then minimizes the errors of
+the other constraints, weighted by their strengths. Children prefer
+their own size and to stay within the layout with Strong strength.
+*/
+package constraint
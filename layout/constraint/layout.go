@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package constraint
+
+import (
+	"image"
+	"math"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+)
+
+// Layout lays out widgets in boxes whose edges are determined by
+// linear constraints. Declare a Layout for every frame: add its
+// children with Child, relate their boxes with Add, and call Layout.
+type Layout struct {
+	// Box is the box of the layout itself. Its top-left corner is at
+	// the origin, and its size is kept within the constraints of the
+	// layout and as small as possible while containing the children.
+	Box
+
+	children    []*child
+	constraints []Constraint
+}
+
+// Box holds the variables of the edges of a rectangle.
+type Box struct {
+	Left, Top, Right, Bottom Var
+	Width, Height            Var
+	CenterX, CenterY         Var
+}
+
+type child struct {
+	box    Box
+	widget layout.Widget
+
+	// Scratch space.
+	call op.CallOp
+	size image.Point
+}
+
+// Child adds a widget and returns its box. The box size prefers the
+// size of the widget laid out with the maximum constraints of the
+// Layout. If the solved size differs, the widget is laid out again
+// with the solved size as exact constraints.
+func (l *Layout) Child(w layout.Widget) *Box {
+	c := &child{widget: w}
+	l.children = append(l.children, c)
+	return &c.box
+}
+
+// Add constraints to the layout. Required constraints that conflict
+// with constraints added earlier are ignored.
+func (l *Layout) Add(cs ...Constraint) {
+	l.constraints = append(l.constraints, cs...)
+}
+
+// Layout the children.
+func (l *Layout) Layout(gtx layout.Context) layout.Dimensions {
+	cs := gtx.Constraints
+	cgtx := gtx
+	cgtx.Constraints = layout.Constraints{Max: cs.Max}
+	for _, c := range l.children {
+		macro := op.Record(gtx.Ops)
+		c.size = c.widget(cgtx).Size
+		c.call = macro.Stop()
+	}
+
+	s := newSolver()
+	scale := gtx.Metric.PxPerDp
+	if scale == 0 {
+		scale = 1
+	}
+	add := func(c Constraint) {
+		// Conflicting constraints are ignored.
+		_ = s.Add(c.px(scale))
+	}
+	add(l.Left.Eq(Const(0)))
+	add(l.Top.Eq(Const(0)))
+	for _, c := range l.box() {
+		add(c)
+	}
+	add(l.Width.Ge(Const(gtx.Metric.PxToDp(cs.Min.X))))
+	add(l.Width.Le(Const(gtx.Metric.PxToDp(cs.Max.X))))
+	add(l.Height.Ge(Const(gtx.Metric.PxToDp(cs.Min.Y))))
+	add(l.Height.Le(Const(gtx.Metric.PxToDp(cs.Max.Y))))
+	add(l.Width.Eq(Const(0)).Strength(Weak))
+	add(l.Height.Eq(Const(0)).Strength(Weak))
+	for _, c := range l.children {
+		b := &c.box
+		for _, c := range b.box() {
+			add(c)
+		}
+		add(b.Width.Eq(Const(gtx.Metric.PxToDp(c.size.X))).Strength(Strong))
+		add(b.Height.Eq(Const(gtx.Metric.PxToDp(c.size.Y))).Strength(Strong))
+		add(b.Left.Ge(&l.Left).Strength(Strong))
+		add(b.Top.Ge(&l.Top).Strength(Strong))
+		add(b.Right.Le(&l.Right).Strength(Strong))
+		add(b.Bottom.Le(&l.Bottom).Strength(Strong))
+	}
+	for _, c := range l.constraints {
+		add(c)
+	}
+	l.Box.solve(s)
+	for _, c := range l.children {
+		c.box.solve(s)
+	}
+
+	for _, c := range l.children {
+		b := &c.box
+		pos := image.Pt(round(b.Left.value), round(b.Top.value))
+		size := image.Pt(round(b.Width.value), round(b.Height.value))
+		if size.X < 0 {
+			size.X = 0
+		}
+		if size.Y < 0 {
+			size.Y = 0
+		}
+		call := c.call
+		if size != c.size {
+			cgtx.Constraints = layout.Exact(size)
+			macro := op.Record(gtx.Ops)
+			c.widget(cgtx)
+			call = macro.Stop()
+		}
+		trans := op.Offset(pos).Push(gtx.Ops)
+		call.Add(gtx.Ops)
+		trans.Pop()
+	}
+	size := cs.Constrain(image.Pt(round(l.Width.value), round(l.Height.value)))
+	return layout.Dimensions{Size: size}
+}
+
+// box returns the constraints that relate the edges of a box.
+func (b *Box) box() []Constraint {
+	return []Constraint{
+		b.Right.Eq(b.Left.Add(&b.Width)),
+		b.Bottom.Eq(b.Top.Add(&b.Height)),
+		b.CenterX.Eq(b.Left.Add(b.Width.Mul(.5))),
+		b.CenterY.Eq(b.Top.Add(b.Height.Mul(.5))),
+		b.Width.Ge(Const(0)),
+		b.Height.Ge(Const(0)),
+	}
+}
+
+func (b *Box) solve(s *solver) {
+	for _, v := range []*Var{
+		&b.Left, &b.Top, &b.Right, &b.Bottom,
+		&b.Width, &b.Height, &b.CenterX, &b.CenterY,
+	} {
+		v.value = float32(s.Value(v))
+	}
+}
+
+func round(v float32) int {
+	return int(math.Round(float64(v)))
+}
@@ -0,0 +1,430 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package constraint
+
+import (
+	"errors"
+	"sort"
+)
+
+// solver is an incremental solver for systems of linear constraints,
+// implementing the Cassowary algorithm as described in "The Cassowary
+// Linear Arithmetic Constraint Solving Algorithm" by Badros, Borning
+// and Stuckey. Its structure follows the Kiwi implementation.
+//
+// Every constraint is converted into a row of a simplex tableau in
+// augmented form, where a basic symbol equals a constant plus a linear
+// combination of parametric symbols. The objective row minimizes the
+// weighted errors of the non-required constraints.
+type solver struct {
+	// rows maps basic symbols to their rows.
+	rows map[symbol]*row
+	// vars maps variables to their symbols.
+	vars map[*Var]symbol
+	// objective is the function to minimize.
+	objective *row
+	// artificial is the objective while adding a row with an
+	// artificial variable.
+	artificial *row
+	nextID     uint32
+}
+
+type symbolKind uint8
+
+// symbol is a variable of the tableau. The zero symbol is invalid.
+type symbol struct {
+	id   uint32
+	kind symbolKind
+}
+
+// row is a linear expression of parametric symbols plus a constant.
+type row struct {
+	cells    map[symbol]float64
+	constant float64
+}
+
+// tag holds the symbols that mark the row of a constraint.
+type tag struct {
+	marker, other symbol
+}
+
+const (
+	invalidSymbol symbolKind = iota
+	// externalSymbol is a symbol of a Var.
+	externalSymbol
+	// slackSymbol is the slack of an inequality.
+	slackSymbol
+	// errorSymbol is the error of a non-required constraint.
+	errorSymbol
+	// dummySymbol marks required equalities.
+	dummySymbol
+)
+
+var (
+	errUnsatisfiable = errors.New("constraint: unsatisfiable constraint")
+	errUnbounded     = errors.New("constraint: unbounded objective")
+)
+
+func newSolver() *solver {
+	return &solver{
+		rows:      make(map[symbol]*row),
+		vars:      make(map[*Var]symbol),
+		objective: newRow(0),
+	}
+}
+
+// Add a constraint to the system. Adding a required constraint that
+// conflicts with the constraints already added fails and leaves the
+// system unchanged.
+func (s *solver) Add(c constraint) error {
+	rows, objective := s.snapshot()
+	if err := s.add(c); err != nil {
+		s.rows, s.objective = rows, objective
+		s.artificial = nil
+		return err
+	}
+	return nil
+}
+
+func (s *solver) add(c constraint) error {
+	r, t := s.createRow(c)
+	subject := chooseSubject(r, t)
+	if subject.kind == invalidSymbol && r.allDummies() {
+		if !nearZero(r.constant) {
+			return errUnsatisfiable
+		}
+		subject = t.marker
+	}
+	if subject.kind == invalidSymbol {
+		ok, err := s.addWithArtificialVariable(r)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errUnsatisfiable
+		}
+	} else {
+		r.solveFor(subject)
+		s.substitute(subject, r)
+		s.rows[subject] = r
+	}
+	return s.optimize(s.objective)
+}
+
+// Value returns the value of v in the current solution.
+func (s *solver) Value(v *Var) float64 {
+	sym, ok := s.vars[v]
+	if !ok {
+		return 0
+	}
+	if r, ok := s.rows[sym]; ok {
+		return r.constant
+	}
+	return 0
+}
+
+// snapshot returns copies of the rows and the objective, for restoring
+// the tableau.
+func (s *solver) snapshot() (map[symbol]*row, *row) {
+	rows := make(map[symbol]*row, len(s.rows))
+	for sym, r := range s.rows {
+		rows[sym] = r.copy()
+	}
+	return rows, s.objective.copy()
+}
+
+func (s *solver) newSymbol(kind symbolKind) symbol {
+	s.nextID++
+	return symbol{id: s.nextID, kind: kind}
+}
+
+// createRow converts a constraint into a row, with the symbols that
+// mark it.
+func (s *solver) createRow(c constraint) (*row, tag) {
+	var t tag
+	r := newRow(c.constant)
+	for _, term := range c.terms {
+		if nearZero(term.coeff) {
+			continue
+		}
+		sym, ok := s.vars[term.v]
+		if !ok {
+			sym = s.newSymbol(externalSymbol)
+			s.vars[term.v] = sym
+		}
+		if basic, ok := s.rows[sym]; ok {
+			r.insertRow(basic, term.coeff)
+		} else {
+			r.insert(sym, term.coeff)
+		}
+	}
+	strength := float64(c.strength)
+	required := c.strength >= Required
+	switch c.op {
+	case opLe, opGe:
+		coeff := 1.0
+		if c.op == opGe {
+			coeff = -1
+		}
+		slack := s.newSymbol(slackSymbol)
+		t.marker = slack
+		r.insert(slack, coeff)
+		if !required {
+			e := s.newSymbol(errorSymbol)
+			t.other = e
+			r.insert(e, -coeff)
+			s.objective.insert(e, strength)
+		}
+	case opEq:
+		if !required {
+			plus := s.newSymbol(errorSymbol)
+			minus := s.newSymbol(errorSymbol)
+			t.marker, t.other = plus, minus
+			r.insert(plus, -1)
+			r.insert(minus, 1)
+			s.objective.insert(plus, strength)
+			s.objective.insert(minus, strength)
+		} else {
+			dummy := s.newSymbol(dummySymbol)
+			t.marker = dummy
+			r.insert(dummy, 1)
+		}
+	}
+	if r.constant < 0 {
+		r.reverseSign()
+	}
+	return r, t
+}
+
+// chooseSubject returns the symbol to solve the row for, or the
+// invalid symbol if the row needs an artificial variable.
+func chooseSubject(r *row, t tag) symbol {
+	for _, sym := range r.symbols() {
+		if sym.kind == externalSymbol {
+			return sym
+		}
+	}
+	for _, m := range []symbol{t.marker, t.other} {
+		if (m.kind == slackSymbol || m.kind == errorSymbol) && r.coefficientFor(m) < 0 {
+			return m
+		}
+	}
+	return symbol{}
+}
+
+// addWithArtificialVariable adds r to the tableau by optimizing an
+// artificial variable to zero. It reports whether the row could be
+// satisfied.
+func (s *solver) addWithArtificialVariable(r *row) (bool, error) {
+	art := s.newSymbol(slackSymbol)
+	s.rows[art] = r.copy()
+	s.artificial = r.copy()
+	if err := s.optimize(s.artificial); err != nil {
+		return false, err
+	}
+	success := nearZero(s.artificial.constant)
+	s.artificial = nil
+
+	if basic, ok := s.rows[art]; ok {
+		delete(s.rows, art)
+		if len(basic.cells) == 0 {
+			return success, nil
+		}
+		entering := anyPivotableSymbol(basic)
+		if entering.kind == invalidSymbol {
+			return false, nil
+		}
+		basic.solveForEx(art, entering)
+		s.substitute(entering, basic)
+		s.rows[entering] = basic
+	}
+	for _, r := range s.rows {
+		r.remove(art)
+	}
+	s.objective.remove(art)
+	return success, nil
+}
+
+// substitute replaces sym with r in every row of the tableau.
+func (s *solver) substitute(sym symbol, r *row) {
+	for _, basic := range s.rows {
+		basic.substitute(sym, r)
+	}
+	s.objective.substitute(sym, r)
+	if s.artificial != nil {
+		s.artificial.substitute(sym, r)
+	}
+}
+
+// optimize the objective with the primal simplex method.
+func (s *solver) optimize(objective *row) error {
+	for {
+		entering := enteringSymbol(objective)
+		if entering.kind == invalidSymbol {
+			return nil
+		}
+		leaving, ok := s.leavingSymbol(entering)
+		if !ok {
+			return errUnbounded
+		}
+		r := s.rows[leaving]
+		delete(s.rows, leaving)
+		r.solveForEx(leaving, entering)
+		s.substitute(entering, r)
+		s.rows[entering] = r
+	}
+}
+
+// enteringSymbol returns the first symbol with a negative coefficient in
+// the objective, or the invalid symbol if the objective is optimal.
+func enteringSymbol(objective *row) symbol {
+	for _, sym := range objective.symbols() {
+		if sym.kind != dummySymbol && objective.cells[sym] < 0 {
+			return sym
+		}
+	}
+	return symbol{}
+}
+
+// leavingSymbol returns the basic symbol of the row that most restricts
+// the entering symbol.
+func (s *solver) leavingSymbol(entering symbol) (symbol, bool) {
+	var (
+		leaving symbol
+		min     float64
+		found   bool
+	)
+	for _, sym := range s.basicSymbols() {
+		if sym.kind == externalSymbol {
+			continue
+		}
+		r := s.rows[sym]
+		c := r.coefficientFor(entering)
+		if c >= 0 {
+			continue
+		}
+		if ratio := -r.constant / c; !found || ratio < min {
+			leaving, min, found = sym, ratio, true
+		}
+	}
+	return leaving, found
+}
+
+// anyPivotableSymbol returns a slack or error symbol of r.
+func anyPivotableSymbol(r *row) symbol {
+	for _, sym := range r.symbols() {
+		if sym.kind == slackSymbol || sym.kind == errorSymbol {
+			return sym
+		}
+	}
+	return symbol{}
+}
+
+// basicSymbols returns the basic symbols in a deterministic order.
+func (s *solver) basicSymbols() []symbol {
+	syms := make([]symbol, 0, len(s.rows))
+	for sym := range s.rows {
+		syms = append(syms, sym)
+	}
+	sortSymbols(syms)
+	return syms
+}
+
+func newRow(constant float64) *row {
+	return &row{cells: make(map[symbol]float64), constant: constant}
+}
+
+func (r *row) copy() *row {
+	c := newRow(r.constant)
+	for sym, v := range r.cells {
+		c.cells[sym] = v
+	}
+	return c
+}
+
+// symbols returns the parametric symbols of r in a deterministic order.
+func (r *row) symbols() []symbol {
+	syms := make([]symbol, 0, len(r.cells))
+	for sym := range r.cells {
+		syms = append(syms, sym)
+	}
+	sortSymbols(syms)
+	return syms
+}
+
+func (r *row) allDummies() bool {
+	for sym := range r.cells {
+		if sym.kind != dummySymbol {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *row) insert(sym symbol, coeff float64) {
+	v := r.cells[sym] + coeff
+	if nearZero(v) {
+		delete(r.cells, sym)
+	} else {
+		r.cells[sym] = v
+	}
+}
+
+// insertRow adds other, multiplied by coeff, to r.
+func (r *row) insertRow(other *row, coeff float64) {
+	r.constant += other.constant * coeff
+	for sym, v := range other.cells {
+		r.insert(sym, v*coeff)
+	}
+}
+
+func (r *row) remove(sym symbol) {
+	delete(r.cells, sym)
+}
+
+func (r *row) reverseSign() {
+	r.constant = -r.constant
+	for sym, v := range r.cells {
+		r.cells[sym] = -v
+	}
+}
+
+// solveFor solves the row, which equals zero, for sym, which becomes
+// the basic symbol of the row.
+func (r *row) solveFor(sym symbol) {
+	coeff := -1 / r.cells[sym]
+	delete(r.cells, sym)
+	r.constant *= coeff
+	for s, v := range r.cells {
+		r.cells[s] = v * coeff
+	}
+}
+
+// solveForEx solves the row, whose basic symbol is lhs, for rhs.
+func (r *row) solveForEx(lhs, rhs symbol) {
+	r.insert(lhs, -1)
+	r.solveFor(rhs)
+}
+
+func (r *row) coefficientFor(sym symbol) float64 {
+	return r.cells[sym]
+}
+
+// substitute replaces sym with the expression of other.
+func (r *row) substitute(sym symbol, other *row) {
+	if c, ok := r.cells[sym]; ok {
+		delete(r.cells, sym)
+		r.insertRow(other, c)
+	}
+}
+
+func sortSymbols(syms []symbol) {
+	sort.Slice(syms, func(i, j int) bool {
+		return syms[i].id < syms[j].id
+	})
+}
+
+func nearZero(v float64) bool {
+	const eps = 1e-8
+	return -eps < v && v < eps
+}
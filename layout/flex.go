@@ -6,6 +6,7 @@ import (
 	"image"
 
 	"gioui.org/op"
+	"gioui.org/unit"
 )
 
 // Flex lays out child elements along an axis,
@@ -22,6 +23,13 @@ type Flex struct {
 	// size of Flexed children. If WeightSum is zero, the sum
 	// of all Flexed weights is used.
 	WeightSum float32
+	// Gap is the space between adjacent children.
+	Gap unit.Dp
+	// Shrink enables shrinking of Rigid children that don't fit.
+	// If set, Rigid children are measured in all of the available
+	// space and, if they together exceed it, each is limited to a
+	// size proportional to its measured size such that they fit.
+	Shrink bool
 }
 
 // FlexChild is the descriptor for a Flex child.
@@ -31,9 +39,14 @@ type FlexChild struct {
 
 	widget Widget
 
+	// min and max bound the main axis size. A zero max means
+	// no bound.
+	min, max unit.Dp
+
 	// Scratch space.
-	call op.CallOp
-	dims Dimensions
+	call  op.CallOp
+	dims  Dimensions
+	limit int
 }
 
 // Spacing determine the spacing mode for a Flex.
@@ -77,15 +90,35 @@ func Flexed(weight float32, widget Widget) FlexChild {
 	}
 }
 
+// Bounds returns the child with its main axis size limited to the range
+// [min, max]. A zero max leaves the size unbounded from above. The
+// minimum takes precedence over the space left in the Flex, so children
+// with a minimum size may overflow.
+func (c FlexChild) Bounds(min, max unit.Dp) FlexChild {
+	c.min, c.max = min, max
+	return c
+}
+
 // Layout a list of children. The position of the children are
 // determined by the specified order, but Rigid children are laid out
 // before Flexed children.
 func (f Flex) Layout(gtx Context, children ...FlexChild) Dimensions {
-	size := 0
 	cs := gtx.Constraints
 	mainMin, mainMax := f.Axis.mainConstraint(cs)
 	crossMin, crossMax := f.Axis.crossConstraint(cs)
-	remaining := mainMax
+	gap := gtx.Dp(f.Gap)
+	var gaps int
+	if len(children) > 1 {
+		gaps = gap * (len(children) - 1)
+	}
+	size := gaps
+	remaining := mainMax - gaps
+	if remaining < 0 {
+		remaining = 0
+	}
+	if f.Shrink {
+		f.shrink(gtx, children, remaining)
+	}
 	var totalWeight float32
 	cgtx := gtx
 	// Lay out Rigid children.
@@ -94,10 +127,24 @@ func (f Flex) Layout(gtx Context, children ...FlexChild) Dimensions {
 			totalWeight += child.weight
 			continue
 		}
-		macro := op.Record(gtx.Ops)
-		cgtx.Constraints = f.Axis.constraints(0, remaining, crossMin, crossMax)
-		dims := child.widget(cgtx)
-		c := macro.Stop()
+		var dims Dimensions
+		var c op.CallOp
+		if f.Shrink && f.Axis.Convert(child.dims.Size).X <= child.limit {
+			// Reuse the measurement of a child that fits.
+			dims, c = child.dims, child.call
+		} else {
+			min, max := child.bounds(gtx, remaining)
+			if f.Shrink && max > child.limit {
+				max = child.limit
+			}
+			if max < min {
+				max = min
+			}
+			macro := op.Record(gtx.Ops)
+			cgtx.Constraints = f.Axis.constraints(min, max, crossMin, crossMax)
+			dims = child.widget(cgtx)
+			c = macro.Stop()
+		}
 		sz := f.Axis.Convert(dims.Size).X
 		size += sz
 		remaining -= sz
@@ -129,6 +176,11 @@ func (f Flex) Layout(gtx Context, children ...FlexChild) Dimensions {
 				flexSize = remaining
 			}
 		}
+		if min, max := child.bounds(gtx, flexSize); flexSize < min {
+			flexSize = min
+		} else if flexSize > max {
+			flexSize = max
+		}
 		macro := op.Record(gtx.Ops)
 		cgtx.Constraints = f.Axis.constraints(flexSize, flexSize, crossMin, crossMax)
 		dims := child.widget(cgtx)
@@ -189,6 +241,7 @@ func (f Flex) Layout(gtx Context, children ...FlexChild) Dimensions {
 		trans.Pop()
 		mainSize += f.Axis.Convert(dims.Size).X
 		if i < len(children)-1 {
+			mainSize += gap
 			switch f.Spacing {
 			case SpaceEvenly:
 				mainSize += space / (1 + len(children))
@@ -220,6 +273,54 @@ func (f Flex) Layout(gtx Context, children ...FlexChild) Dimensions {
 	return Dimensions{Size: sz, Baseline: sz.Y - maxBaseline}
 }
 
+// shrink measures the Rigid children in space and computes their size
+// limits such that they fit, in proportion to their measured sizes.
+// Children that fit their limits keep their measurements for Layout.
+func (f Flex) shrink(gtx Context, children []FlexChild, space int) {
+	crossMin, crossMax := f.Axis.crossConstraint(gtx.Constraints)
+	cgtx := gtx
+	total := 0
+	for i, child := range children {
+		if child.flex {
+			continue
+		}
+		min, max := child.bounds(gtx, space)
+		if max < min {
+			max = min
+		}
+		macro := op.Record(gtx.Ops)
+		cgtx.Constraints = f.Axis.constraints(min, max, crossMin, crossMax)
+		dims := child.widget(cgtx)
+		children[i].call = macro.Stop()
+		children[i].dims = dims
+		sz := f.Axis.Convert(dims.Size).X
+		children[i].limit = sz
+		total += sz
+	}
+	if total <= space {
+		return
+	}
+	for i, child := range children {
+		if child.flex {
+			continue
+		}
+		children[i].limit = int(int64(child.limit) * int64(space) / int64(total))
+	}
+}
+
+// bounds returns the main axis size range of the child, given the
+// available space.
+func (c FlexChild) bounds(gtx Context, space int) (min, max int) {
+	min = gtx.Dp(c.min)
+	max = space
+	if c.max != 0 {
+		if m := gtx.Dp(c.max); m < max {
+			max = m
+		}
+	}
+	return min, max
+}
+
 func (s Spacing) String() string {
 	switch s {
 	case SpaceEnd:
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"gioui.org/op"
+	"gioui.org/unit"
 )
 
 func TestStack(t *testing.T) {
@@ -44,6 +45,86 @@ func TestFlex(t *testing.T) {
 	}
 }
 
+func TestFlexGapAndBounds(t *testing.T) {
+	gtx := Context{
+		Ops: new(op.Ops),
+		Constraints: Constraints{
+			Max: image.Pt(100, 100),
+		},
+	}
+	var sizes []int
+	child := func(w int) Widget {
+		return func(gtx Context) Dimensions {
+			sz := gtx.Constraints.Constrain(image.Pt(w, 10))
+			sizes = append(sizes, sz.X)
+			return Dimensions{Size: sz}
+		}
+	}
+	dims := Flex{Gap: 10}.Layout(gtx,
+		Rigid(child(5)).Bounds(20, 0),
+		Flexed(1, child(0)).Bounds(0, 15),
+		Rigid(child(50)).Bounds(0, 40),
+	)
+	// Rigid children are laid out first.
+	if want := []int{20, 40, 15}; !equalInts(sizes, want) {
+		t.Errorf("child sizes %v, want %v", sizes, want)
+	}
+	if got, want := dims.Size.X, 20+10+15+10+40; got != want {
+		t.Errorf("Flex width %d, want %d", got, want)
+	}
+}
+
+func TestFlexShrink(t *testing.T) {
+	gtx := Context{
+		Ops: new(op.Ops),
+		Constraints: Constraints{
+			Max: image.Pt(100, 100),
+		},
+	}
+	var sizes []int
+	child := func(w int) Widget {
+		return func(gtx Context) Dimensions {
+			sz := gtx.Constraints.Constrain(image.Pt(w, 10))
+			sizes = append(sizes, sz.X)
+			return Dimensions{Size: sz}
+		}
+	}
+	dims := Flex{Shrink: true}.Layout(gtx,
+		Rigid(child(150)),
+		Rigid(child(50)),
+	)
+	// The first two sizes are measurements in the available space, and
+	// the children shrink in proportion to them.
+	if want := []int{100, 50, 66, 33}; !equalInts(sizes, want) {
+		t.Errorf("child sizes %v, want %v", sizes, want)
+	}
+	if got := dims.Size.X; got > 100 {
+		t.Errorf("shrunk Flex width %d exceeds the maximum", got)
+	}
+
+	// Children that fit are laid out once.
+	sizes = nil
+	Flex{Shrink: true}.Layout(gtx,
+		Rigid(child(30)),
+		Rigid(child(50)),
+	)
+	if want := []int{30, 50}; !equalInts(sizes, want) {
+		t.Errorf("child sizes %v, want %v", sizes, want)
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func TestDirection(t *testing.T) {
 	max := image.Pt(100, 100)
 	for _, tc := range []struct {
@@ -142,3 +223,67 @@ func TestConstraints(t *testing.T) {
 		})
 	}
 }
+
+func TestResponsive(t *testing.T) {
+	gtx := Context{
+		Ops:    new(op.Ops),
+		Metric: unit.Metric{PxPerDp: 2},
+	}
+	picked := ""
+	pick := func(name string) Widget {
+		return func(gtx Context) Dimensions {
+			picked = name
+			return Dimensions{}
+		}
+	}
+	r := Responsive{
+		{Widget: pick("phone")},
+		{MinWidth: 600, Widget: pick("tablet")},
+		{MinWidth: 600, Orientation: Landscape, Widget: pick("tablet landscape")},
+		{MinWidth: 1200, MinHeight: 600, Widget: pick("desktop")},
+	}
+	classes := SizeClasses{Compact: pick("compact"), Expanded: pick("expanded")}
+	tests := []struct {
+		size        image.Point
+		class       SizeClass
+		breakpoint  string
+		classWidget string
+	}{
+		{image.Pt(800, 1600), SizeCompact, "phone", "compact"},
+		{image.Pt(1400, 2000), SizeMedium, "tablet", "compact"},
+		{image.Pt(1400, 1000), SizeMedium, "tablet landscape", "compact"},
+		{image.Pt(2400, 1000), SizeExpanded, "tablet landscape", "expanded"},
+		{image.Pt(2400, 1200), SizeExpanded, "desktop", "expanded"},
+	}
+	for _, test := range tests {
+		gtx.Constraints = Exact(test.size)
+		if got := gtx.WidthClass(); got != test.class {
+			t.Errorf("%v: width class %v, want %v", test.size, got, test.class)
+		}
+		r.Layout(gtx)
+		if picked != test.breakpoint {
+			t.Errorf("%v: picked breakpoint %q, want %q", test.size, picked, test.breakpoint)
+		}
+		classes.Layout(gtx)
+		if picked != test.classWidget {
+			t.Errorf("%v: picked size class widget %q, want %q", test.size, picked, test.classWidget)
+		}
+	}
+}
+
+func TestResponsiveString(t *testing.T) {
+	tests := []struct {
+		v    interface{ String() string }
+		want string
+	}{
+		{SizeCompact, "SizeCompact"},
+		{SizeExpanded, "SizeExpanded"},
+		{AnyOrientation, "AnyOrientation"},
+		{Landscape, "Landscape"},
+	}
+	for _, test := range tests {
+		if got := test.v.String(); got != test.want {
+			t.Errorf("String() = %q, want %q", got, test.want)
+		}
+	}
+}
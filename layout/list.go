@@ -5,6 +5,7 @@ package layout
 import (
 	"image"
 	"math"
+	"time"
 
 	"gioui.org/gesture"
 	"gioui.org/op"
@@ -28,6 +29,15 @@ type List struct {
 	ScrollToEnd bool
 	// Alignment is the cross axis alignment of list elements.
 	Alignment Alignment
+	// SmoothScroll enables smooth scrolling of mouse wheel movements.
+	SmoothScroll bool
+	// ScrollDuration is the duration of animated scrolling. If zero,
+	// a default duration is used.
+	ScrollDuration time.Duration
+	// ScrollEasing maps the linear progress of animated scrolling in
+	// the range [0,1] to eased progress. If nil, a cubic ease out curve
+	// is used.
+	ScrollEasing func(t float32) float32
 
 	cs          Constraints
 	scroll      gesture.Scroll
@@ -45,6 +55,74 @@ type List struct {
 	maxSize  int
 	children []scrollChild
 	dir      iterationDir
+
+	// target is a pending aligned scroll.
+	target scrollTarget
+	anim   scrollAnimation
+
+	// overscroll is the distance dragged beyond the ends of the list,
+	// and overscrollTime the time of its last relaxation.
+	overscroll     float32
+	overscrollTime time.Time
+	// clamped is the change of Position.Offset from clamping to the
+	// ends of the list.
+	clamped int
+
+	// sizes is scratch space for the main axis sizes of elements laid
+	// out by LayoutSections, starting at element sizesFirst.
+	sizes      []int
+	sizesFirst int
+	// headers is scratch space for the header elements laid out by
+	// LayoutSections.
+	headers []sectionHeader
+}
+
+// sectionHeader is a recorded header element of a LayoutSections list.
+type sectionHeader struct {
+	index int
+	call  op.CallOp
+	dims  Dimensions
+}
+
+// ScrollAlignment specifies where an element scrolled to is placed in the
+// visible area of a List.
+type ScrollAlignment uint8
+
+const (
+	// ScrollStart places the element at the leading edge.
+	ScrollStart ScrollAlignment = iota
+	// ScrollCenter centers the element.
+	ScrollCenter
+	// ScrollEnd places the element at the trailing edge.
+	ScrollEnd
+	// ScrollNearest scrolls the least distance that makes the element
+	// visible. A visible element is not scrolled.
+	ScrollNearest
+)
+
+// scrollTarget is an element to be aligned during the next Layout.
+type scrollTarget struct {
+	active bool
+	index  int
+	align  ScrollAlignment
+}
+
+// scrollAnimation tracks the progress of an animated scroll.
+type scrollAnimation struct {
+	active bool
+	// started tracks whether the animation start time and distance are
+	// known.
+	started bool
+	start   time.Time
+	// distance is the total distance in pixels, and scrolled the
+	// distance scrolled so far.
+	distance, scrolled int
+	// items is the distance in elements, converted to pixels when
+	// the animation starts.
+	items float32
+	// target is the element to align at the end of the animation, if
+	// active.
+	target scrollTarget
 }
 
 // ListElement is a function that computes the dimensions of
@@ -86,6 +164,14 @@ const (
 
 const inf = 1e6
 
+// defaultScrollDuration is the duration of animated scrolling if
+// ScrollDuration is zero.
+const defaultScrollDuration = 300 * time.Millisecond
+
+// overscrollRelaxation is the time constant of the return of an
+// overscroll to zero after dragging stops.
+const overscrollRelaxation = 50 * time.Millisecond
+
 // init prepares the list for iterating through its children with next.
 func (l *List) init(gtx Context, len int) {
 	if l.more() {
@@ -93,9 +179,24 @@ func (l *List) init(gtx Context, len int) {
 	}
 	l.cs = gtx.Constraints
 	l.maxSize = 0
+	l.clamped = 0
 	l.children = l.children[:0]
 	l.len = len
 	l.update(gtx)
+	l.animate(gtx)
+	if t := l.target; t.active {
+		// Start from the target element. Its exact offset is computed
+		// when it is laid out.
+		l.Position.First = t.index
+		l.Position.Offset = 0
+		_, vsize := l.Axis.mainConstraint(l.cs)
+		switch t.align {
+		case ScrollCenter:
+			l.Position.Offset = -vsize / 2
+		case ScrollEnd:
+			l.Position.Offset = -vsize
+		}
+	}
 	if l.Position.First < 0 {
 		l.Position.Offset = 0
 		l.Position.First = 0
@@ -134,6 +235,102 @@ func (l *List) Layout(gtx Context, len int, w ListElement) Dimensions {
 	return l.layout(gtx.Ops, macro)
 }
 
+// LayoutSections is like Layout, except that the list is divided into
+// sections, each starting with a header element. The header of the
+// section of the first visible element is pinned to the leading edge
+// of the list, until it is pushed out by the header of the next section.
+//
+// The header function returns the index of the header element for the
+// section containing the element at index, or -1 if the element is not
+// in a section. The index of a header element is its own header index.
+func (l *List) LayoutSections(gtx Context, len int, header func(index int) int, w ListElement) Dimensions {
+	l.sizes = l.sizes[:0]
+	l.headers = l.headers[:0]
+	dims := l.Layout(gtx, len, func(gtx Context, index int) Dimensions {
+		if header(index) != index {
+			d := w(gtx, index)
+			l.recordSize(index, l.Axis.Convert(d.Size).X)
+			return d
+		}
+		// Record headers for replaying a pinned header.
+		macro := op.Record(gtx.Ops)
+		d := w(gtx, index)
+		call := macro.Stop()
+		call.Add(gtx.Ops)
+		l.headers = append(l.headers, sectionHeader{index: index, call: call, dims: d})
+		l.recordSize(index, l.Axis.Convert(d.Size).X)
+		return d
+	})
+	first := l.Position.First
+	h := -1
+	if first < len {
+		h = header(first)
+	}
+	if h < 0 || h == first && l.Position.Offset <= 0 {
+		// No section, or the header is in place.
+		return dims
+	}
+	// Replay the pinned header if the list laid it out, or lay it out.
+	var call op.CallOp
+	var hdims Dimensions
+	found := false
+	for _, sh := range l.headers {
+		if sh.index == h {
+			call, hdims, found = sh.call, sh.dims, true
+			break
+		}
+	}
+	if !found {
+		crossMin, crossMax := l.Axis.crossConstraint(gtx.Constraints)
+		cgtx := gtx
+		cgtx.Constraints = l.Axis.constraints(0, inf, crossMin, crossMax)
+		macro := op.Record(gtx.Ops)
+		hdims = w(cgtx, h)
+		call = macro.Stop()
+	}
+	hsize := l.Axis.Convert(hdims.Size).X
+	// Push the pinned header out by the next visible header.
+	pos := -l.Position.Offset
+	off := 0
+	for j, sz := range l.sizes {
+		i := l.sizesFirst + j
+		if i < first {
+			continue
+		}
+		if i >= first+l.Position.Count {
+			break
+		}
+		if i > first && header(i) == i {
+			if pos < hsize {
+				off = pos - hsize
+			}
+			break
+		}
+		pos += sz
+	}
+	defer clip.Rect{Max: dims.Size}.Push(gtx.Ops).Pop()
+	defer op.Offset(l.Axis.Convert(image.Pt(off, 0))).Push(gtx.Ops).Pop()
+	call.Add(gtx.Ops)
+	return dims
+}
+
+// recordSize records the main axis size of an element laid out by
+// LayoutSections.
+func (l *List) recordSize(index, size int) {
+	switch {
+	case len(l.sizes) == 0:
+		l.sizesFirst = index
+		l.sizes = append(l.sizes, size)
+	case l.dir == iterateBackward:
+		l.sizes = append(l.sizes, 0)
+		copy(l.sizes[1:], l.sizes)
+		l.sizes[0] = size
+		l.sizesFirst = index
+	default:
+		l.sizes = append(l.sizes, size)
+	}
+}
+
 func (l *List) scrollToEnd() bool {
 	return l.ScrollToEnd && !l.Position.BeforeEnd
 }
@@ -144,11 +341,149 @@ func (l *List) Dragging() bool {
 }
 
 func (l *List) update(gtx Context) {
+	l.scroll.Smooth = l.SmoothScroll
 	d := l.scroll.Update(gtx.Metric, gtx, gtx.Now, gesture.Axis(l.Axis))
+	if d != 0 {
+		// User scrolling cancels animated scrolling.
+		l.anim = scrollAnimation{}
+	}
+	d = l.updateOverscroll(gtx, d)
 	l.scrollDelta = d
 	l.Position.Offset += d
 }
 
+// updateOverscroll tracks the distance dragged beyond the ends of the
+// list, and returns the part of the scroll distance d that scrolls
+// the list.
+func (l *List) updateOverscroll(gtx Context, d int) int {
+	if l.scroll.State() != gesture.StateDragging {
+		if l.overscroll == 0 {
+			return d
+		}
+		// Relax to zero.
+		dt := gtx.Now.Sub(l.overscrollTime)
+		if l.overscrollTime.IsZero() || dt < 0 {
+			dt = 0
+		}
+		l.overscroll *= float32(math.Exp(-float64(dt) / float64(overscrollRelaxation)))
+		l.overscrollTime = gtx.Now
+		if -1 < l.overscroll && l.overscroll < 1 {
+			l.overscroll = 0
+		}
+		return d
+	}
+	l.overscrollTime = gtx.Now
+	// Dragging back reduces the overscroll before scrolling.
+	o := int(l.overscroll)
+	switch {
+	case o < 0 && d > 0:
+		c := d
+		if c > -o {
+			c = -o
+		}
+		l.overscroll += float32(c)
+		d -= c
+	case o > 0 && d < 0:
+		c := d
+		if c < -o {
+			c = -o
+		}
+		l.overscroll += float32(c)
+		d -= c
+	}
+	return d
+}
+
+// Overscroll returns the distance in pixels that the list is dragged
+// beyond its start (negative) or its end (positive). The distance
+// returns to zero in a short animation when the drag ends.
+func (l *List) Overscroll() int {
+	return int(l.overscroll)
+}
+
+// animate advances an animated scroll.
+func (l *List) animate(gtx Context) {
+	a := &l.anim
+	if !a.active {
+		return
+	}
+	if !a.started {
+		a.started = true
+		a.start = gtx.Now
+		a.distance = l.animationDistance()
+	}
+	dur := l.ScrollDuration
+	if dur <= 0 {
+		dur = defaultScrollDuration
+	}
+	progress := float32(gtx.Now.Sub(a.start)) / float32(dur)
+	if progress >= 1 {
+		l.scrollPixels(a.distance - a.scrolled)
+		l.target = a.target
+		*a = scrollAnimation{}
+		return
+	}
+	if progress < 0 {
+		progress = 0
+	}
+	ease := l.ScrollEasing
+	if ease == nil {
+		ease = easeOutCubic
+	}
+	scrolled := int(math.Round(float64(float32(a.distance) * ease(progress))))
+	l.scrollPixels(scrolled - a.scrolled)
+	a.scrolled = scrolled
+}
+
+// scrollPixels scrolls by d pixels. Like ScrollBy, whole elements of the
+// average size are moved into Position.First, so that Layout doesn't
+// lay out the elements scrolled past.
+func (l *List) scrollPixels(d int) {
+	off := l.Position.Offset + d
+	if l.len > 0 && l.Position.Length > 0 {
+		avg := float64(l.Position.Length) / float64(l.len)
+		n := int(float64(off) / avg)
+		l.Position.First += n
+		off -= int(math.Round(float64(n) * avg))
+	}
+	l.Position.Offset = off
+}
+
+// animationDistance estimates the distance in pixels of the current
+// animation from the average size of the elements laid out so far.
+func (l *List) animationDistance() int {
+	if l.len == 0 {
+		return 0
+	}
+	avg := float32(l.Position.Length) / float32(l.len)
+	a := l.anim
+	if !a.target.active {
+		return int(math.Round(float64(a.items * avg)))
+	}
+	_, vsize := l.Axis.mainConstraint(l.cs)
+	pos := float32(l.Position.First)*avg + float32(l.Position.Offset)
+	target := float32(a.target.index) * avg
+	switch a.target.align {
+	case ScrollCenter:
+		target += (avg - float32(vsize)) / 2
+	case ScrollEnd:
+		target += avg - float32(vsize)
+	}
+	if max := float32(l.Position.Length - vsize); target > max {
+		target = max
+	}
+	if target < 0 {
+		target = 0
+	}
+	return int(math.Round(float64(target - pos)))
+}
+
+// easeOutCubic is the default easing curve of animated scrolling.
+func easeOutCubic(t float32) float32 {
+	t = 1 - t
+	return 1 - t*t*t
+}
+
 // next advances to the next child.
 func (l *List) next() {
 	l.dir = l.nextDir()
@@ -182,12 +517,14 @@ func (l *List) nextDir() iterationDir {
 	_, vsize := l.Axis.mainConstraint(l.cs)
 	last := l.Position.First + len(l.children)
 	// Clamp offset.
+	off := l.Position.Offset
 	if l.maxSize-l.Position.Offset < vsize && last == l.len {
 		l.Position.Offset = l.maxSize - vsize
 	}
 	if l.Position.Offset < 0 && l.Position.First == 0 {
 		l.Position.Offset = 0
 	}
+	l.clamped += l.Position.Offset - off
 	// Lay out an extra (invisible) child at each end to enable focus to
 	// move to them, triggering automatic scroll.
 	firstSize, lastSize := 0, 0
@@ -219,6 +556,21 @@ func (l *List) end(dims Dimensions, call op.CallOp) {
 	l.maxSize += mainSize
 	switch l.dir {
 	case iterateForward:
+		if t := l.target; t.active && l.Position.First+len(l.children) == t.index {
+			// Align the target element now that its size is known.
+			_, vsize := l.Axis.mainConstraint(l.cs)
+			// space is the distance from the leading edge to the
+			// target.
+			var space int
+			switch t.align {
+			case ScrollCenter:
+				space = (vsize - mainSize) / 2
+			case ScrollEnd:
+				space = vsize - mainSize
+			}
+			l.Position.Offset = l.maxSize - mainSize - space
+			l.target = scrollTarget{}
+		}
 		l.children = append(l.children, child)
 	case iterateBackward:
 		l.children = append(l.children, scrollChild{})
@@ -316,6 +668,21 @@ func (l *List) layout(ops *op.Ops, macro op.MacroOp) Dimensions {
 	if atStart && l.scrollDelta < 0 || atEnd && l.scrollDelta > 0 {
 		l.scroll.Stop()
 	}
+	// The part of a drag clamped by the ends of the list is overscroll.
+	if d, c := l.scrollDelta, l.clamped; l.scroll.State() == gesture.StateDragging {
+		switch {
+		case d < 0 && c > 0:
+			if c > -d {
+				c = -d
+			}
+			l.overscroll -= float32(c)
+		case d > 0 && c < 0:
+			if c < -d {
+				c = -d
+			}
+			l.overscroll -= float32(c)
+		}
+	}
 	l.Position.BeforeEnd = !atEnd
 	if pos < mainMin {
 		pos = mainMin
@@ -346,6 +713,11 @@ func (l *List) layout(ops *op.Ops, macro op.MacroOp) Dimensions {
 			max = 0
 		}
 	}
+	// Discard targets that weren't laid out.
+	l.target = scrollTarget{}
+	if l.anim.active || l.overscroll != 0 {
+		op.InvalidateOp{}.Add(ops)
+	}
 	scrollRange := image.Rectangle{
 		Min: l.Axis.Convert(image.Pt(min, 0)),
 		Max: l.Axis.Convert(image.Pt(max, 0)),
@@ -386,4 +758,69 @@ func (l *List) ScrollTo(n int) {
 	l.Position.First = n
 	l.Position.Offset = 0
 	l.Position.BeforeEnd = true
+	l.anim = scrollAnimation{}
+}
+
+// ScrollToAligned scrolls to the specified item and places it according
+// to align. The item is aligned during the next Layout.
+func (l *List) ScrollToAligned(n int, align ScrollAlignment) {
+	align, ok := l.resolveAlignment(n, align)
+	l.anim = scrollAnimation{}
+	if !ok {
+		return
+	}
+	l.target = scrollTarget{active: true, index: n, align: align}
+	l.Position.BeforeEnd = true
+}
+
+// AnimateScrollTo is like ScrollToAligned, except that the list scrolls
+// to the item in an animation over ScrollDuration. The animation
+// distance is estimated from the average size of the items laid out so
+// far and any error is corrected at the end of the animation.
+//
+// The animation is cancelled by user scrolling and by calls to ScrollTo,
+// ScrollToAligned, AnimateScrollTo and AnimateScrollBy.
+func (l *List) AnimateScrollTo(n int, align ScrollAlignment) {
+	align, ok := l.resolveAlignment(n, align)
+	l.target = scrollTarget{}
+	l.anim = scrollAnimation{}
+	if !ok {
+		return
+	}
+	l.anim = scrollAnimation{
+		active: true,
+		target: scrollTarget{active: true, index: n, align: align},
+	}
+	l.Position.BeforeEnd = true
+}
+
+// AnimateScrollBy is like ScrollBy, except that the list scrolls in an
+// animation over ScrollDuration.
+func (l *List) AnimateScrollBy(num float32) {
+	l.target = scrollTarget{}
+	l.anim = scrollAnimation{active: true, items: num}
+	l.Position.BeforeEnd = true
+}
+
+// resolveAlignment resolves ScrollNearest into ScrollStart or ScrollEnd
+// according to the position of the last Layout. It returns false if the
+// item is completely visible and doesn't need scrolling.
+func (l *List) resolveAlignment(n int, align ScrollAlignment) (ScrollAlignment, bool) {
+	if align != ScrollNearest {
+		return align, true
+	}
+	p := l.Position
+	last := p.First + p.Count - 1
+	switch {
+	case n < p.First || n == p.First && p.Offset > 0:
+		return ScrollStart, true
+	case n > last || n == last && p.OffsetLast < 0:
+		return ScrollEnd, true
+	}
+	return align, false
+}
+
+// Animating reports whether the List is scrolling in an animation.
+func (l *List) Animating() bool {
+	return l.anim.active
 }
@@ -5,12 +5,14 @@ package layout
 import (
 	"image"
 	"testing"
+	"time"
 
 	"gioui.org/f32"
 	"gioui.org/io/event"
 	"gioui.org/io/pointer"
 	"gioui.org/io/router"
 	"gioui.org/op"
+	"gioui.org/op/clip"
 )
 
 func TestListPositionExtremes(t *testing.T) {
@@ -195,3 +197,233 @@ func TestExtraChildren(t *testing.T) {
 		t.Errorf("laid out %d of %d children", count, all)
 	}
 }
+
+func TestListScrollToAligned(t *testing.T) {
+	gtx := Context{
+		Ops:         new(op.Ops),
+		Constraints: Exact(image.Pt(10, 100)),
+	}
+	el := func(gtx Context, idx int) Dimensions {
+		return Dimensions{Size: image.Pt(10, 20)}
+	}
+	l := List{Axis: Vertical}
+	for _, tc := range []struct {
+		label  string
+		index  int
+		align  ScrollAlignment
+		first  int
+		offset int
+	}{
+		{label: "start", index: 10, align: ScrollStart, first: 10, offset: 0},
+		{label: "center", index: 20, align: ScrollCenter, first: 18, offset: 0},
+		{label: "end", index: 30, align: ScrollEnd, first: 26, offset: 0},
+		{label: "nearest visible", index: 28, align: ScrollNearest, first: 26, offset: 0},
+		{label: "nearest before", index: 20, align: ScrollNearest, first: 20, offset: 0},
+		{label: "nearest after", index: 40, align: ScrollNearest, first: 36, offset: 0},
+		{label: "center first", index: 0, align: ScrollCenter, first: 0, offset: 0},
+		{label: "end last", index: 99, align: ScrollEnd, first: 95, offset: 0},
+	} {
+		l.ScrollToAligned(tc.index, tc.align)
+		l.Layout(gtx, 100, el)
+		if got, want := l.Position.First, tc.first; got != want {
+			t.Errorf("%s: first %d, want %d", tc.label, got, want)
+		}
+		if got, want := l.Position.Offset, tc.offset; got != want {
+			t.Errorf("%s: offset %d, want %d", tc.label, got, want)
+		}
+	}
+}
+
+func TestListAnimateScrollTo(t *testing.T) {
+	gtx := Context{
+		Ops:         new(op.Ops),
+		Constraints: Exact(image.Pt(10, 100)),
+		Now:         time.Now(),
+	}
+	el := func(gtx Context, idx int) Dimensions {
+		return Dimensions{Size: image.Pt(10, 20)}
+	}
+	l := List{Axis: Vertical, ScrollDuration: 100 * time.Millisecond}
+	l.Layout(gtx, 100, el)
+	l.AnimateScrollTo(50, ScrollStart)
+	prev := 0
+	for i := 0; l.Animating(); i++ {
+		if i > 10 {
+			t.Fatal("animation didn't end")
+		}
+		l.Layout(gtx, 100, el)
+		pos := l.Position.First*20 + l.Position.Offset
+		if pos < prev {
+			t.Errorf("animation moved backwards from %d to %d", prev, pos)
+		}
+		if l.Animating() && pos >= 1000 {
+			t.Errorf("animation reached target before ending")
+		}
+		prev = pos
+		gtx.Now = gtx.Now.Add(20 * time.Millisecond)
+	}
+	l.Layout(gtx, 100, el)
+	if got, want := l.Position.First, 50; got != want {
+		t.Errorf("first %d after animation, want %d", got, want)
+	}
+	if got := l.Position.Offset; got != 0 {
+		t.Errorf("offset %d after animation, want 0", got)
+	}
+}
+
+func TestListAnimateScrollLong(t *testing.T) {
+	gtx := Context{
+		Ops:         new(op.Ops),
+		Constraints: Exact(image.Pt(10, 100)),
+		Now:         time.Now(),
+	}
+	layouts := 0
+	el := func(gtx Context, idx int) Dimensions {
+		layouts++
+		return Dimensions{Size: image.Pt(10, 20)}
+	}
+	const n = 10000
+	l := List{Axis: Vertical, ScrollDuration: 100 * time.Millisecond}
+	l.Layout(gtx, n, el)
+	l.AnimateScrollTo(n-100, ScrollStart)
+	for i := 0; l.Animating(); i++ {
+		if i > 10 {
+			t.Fatal("animation didn't end")
+		}
+		gtx.Now = gtx.Now.Add(20 * time.Millisecond)
+		layouts = 0
+		l.Layout(gtx, n, el)
+		// The visible elements and a few more.
+		if layouts > 20 {
+			t.Fatalf("%d elements laid out in an animation frame", layouts)
+		}
+	}
+	l.Layout(gtx, n, el)
+	if got, want := l.Position.First, n-100; got != want {
+		t.Errorf("first %d after animation, want %d", got, want)
+	}
+}
+
+func TestListSections(t *testing.T) {
+	var (
+		l   = List{Axis: Vertical}
+		r   = new(router.Router)
+		ops = new(op.Ops)
+	)
+	gtx := Context{
+		Ops:         ops,
+		Constraints: Exact(image.Pt(10, 50)),
+		Queue:       r,
+	}
+	tags := make([]int, 100)
+	header := func(index int) int {
+		return index - index%10
+	}
+	// layouts counts the layouts of each element in a frame.
+	layouts := make(map[int]int)
+	el := func(gtx Context, idx int) Dimensions {
+		layouts[idx]++
+		sz := image.Pt(10, 10)
+		defer clip.Rect{Max: sz}.Push(gtx.Ops).Pop()
+		pointer.InputOp{Tag: &tags[idx], Kinds: pointer.Press}.Add(gtx.Ops)
+		return Dimensions{Size: sz}
+	}
+	hit := func(y float32) int {
+		r.Queue(
+			pointer.Event{Kind: pointer.Press, Source: pointer.Touch, Position: f32.Pt(5, y)},
+			pointer.Event{Kind: pointer.Release, Source: pointer.Touch, Position: f32.Pt(5, y)},
+		)
+		for i := range tags {
+			if len(r.Events(&tags[i])) > 0 {
+				return i
+			}
+		}
+		return -1
+	}
+	for _, tc := range []struct {
+		label string
+		pos   Position
+		y     float32
+		want  int
+	}{
+		{label: "header in place", pos: Position{First: 0}, y: 2, want: 0},
+		{label: "pinned header", pos: Position{First: 3, Offset: 5}, y: 2, want: 0},
+		{label: "element below pinned header", pos: Position{First: 3, Offset: 5}, y: 12, want: 4},
+		{label: "pushed header", pos: Position{First: 9, Offset: 5}, y: 2, want: 0},
+		{label: "pushing header", pos: Position{First: 9, Offset: 5}, y: 7, want: 10},
+		{label: "next pinned header", pos: Position{First: 10, Offset: 5}, y: 2, want: 10},
+	} {
+		ops.Reset()
+		l.Position = tc.pos
+		l.Position.BeforeEnd = true
+		layouts = make(map[int]int)
+		l.LayoutSections(gtx, len(tags), header, el)
+		r.Frame(ops)
+		for idx, n := range layouts {
+			if n > 1 {
+				t.Errorf("%s: element %d laid out %d times", tc.label, idx, n)
+			}
+		}
+		if got := hit(tc.y); got != tc.want {
+			t.Errorf("%s: hit element %d, want %d", tc.label, got, tc.want)
+		}
+	}
+}
+
+func TestListOverscroll(t *testing.T) {
+	var (
+		l   = List{Axis: Vertical}
+		r   = new(router.Router)
+		ops = new(op.Ops)
+	)
+	gtx := Context{
+		Ops:         ops,
+		Constraints: Exact(image.Pt(10, 50)),
+		Queue:       r,
+		Now:         time.Now(),
+	}
+	el := func(gtx Context, idx int) Dimensions {
+		return Dimensions{Size: image.Pt(10, 20)}
+	}
+	frame := func() {
+		ops.Reset()
+		l.Layout(gtx, 5, el)
+		r.Frame(ops)
+	}
+	drag := func(kind pointer.Kind, y float32) {
+		r.Queue(pointer.Event{Kind: kind, Source: pointer.Touch, Position: f32.Pt(5, y)})
+		frame()
+	}
+	frame()
+	drag(pointer.Press, 10)
+	drag(pointer.Move, 30)
+	if got, want := l.Overscroll(), -20; got != want {
+		t.Errorf("overscroll %d, want %d", got, want)
+	}
+	// Dragging back reduces the overscroll before scrolling.
+	drag(pointer.Move, 20)
+	if got, want := l.Overscroll(), -10; got != want {
+		t.Errorf("overscroll %d, want %d", got, want)
+	}
+	drag(pointer.Move, 0)
+	if got, want := l.Overscroll(), 0; got != want {
+		t.Errorf("overscroll %d, want %d", got, want)
+	}
+	if got, want := l.Position.Offset, 10; got != want {
+		t.Errorf("offset %d, want %d", got, want)
+	}
+	// Drag beyond the end.
+	drag(pointer.Move, -100)
+	if got, want := l.Overscroll(), 60; got != want {
+		t.Errorf("overscroll %d, want %d", got, want)
+	}
+	// Releasing relaxes the overscroll to zero.
+	drag(pointer.Release, -100)
+	for i := 0; l.Overscroll() != 0; i++ {
+		if i > 100 {
+			t.Fatal("overscroll didn't relax")
+		}
+		gtx.Now = gtx.Now.Add(16 * time.Millisecond)
+		frame()
+	}
+}
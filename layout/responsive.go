@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package layout
+
+import (
+	"gioui.org/unit"
+)
+
+// SizeClass is a coarse classification of the space available to a
+// layout along one axis.
+type SizeClass uint8
+
+// Orientation is the orientation of the space available to a layout.
+type Orientation uint8
+
+// Breakpoint is an alternative widget for a Responsive layout.
+type Breakpoint struct {
+	// MinWidth and MinHeight are the minimum available width and
+	// height for the breakpoint to apply.
+	MinWidth, MinHeight unit.Dp
+	// Orientation, if not AnyOrientation, is the orientation required
+	// for the breakpoint to apply.
+	Orientation Orientation
+	Widget      Widget
+}
+
+// Responsive lays out one of several alternative widgets depending on
+// the available space. The breakpoints are listed from the smallest to
+// the largest, and the last breakpoint that applies to the maximum
+// constraints is laid out.
+type Responsive []Breakpoint
+
+// SizeClasses lays out one of three alternative widgets depending on
+// the size class of the available width. A nil widget is replaced by
+// the widget of the next smaller class.
+type SizeClasses struct {
+	Compact, Medium, Expanded Widget
+}
+
+const (
+	// SizeCompact is the class of widths below 600dp, typical of phones in
+	// portrait, and of heights below 480dp, typical of phones in
+	// landscape.
+	SizeCompact SizeClass = iota
+	// SizeMedium is the class of widths below 840dp, typical of tablets in
+	// portrait and foldables, and of heights below 900dp.
+	SizeMedium
+	// SizeExpanded is the class of larger widths and heights, typical of
+	// tablets in landscape and desktop windows.
+	SizeExpanded
+)
+
+const (
+	// AnyOrientation matches both orientations.
+	AnyOrientation Orientation = iota
+	// Portrait orientation is taller than wide, or square.
+	Portrait
+	// Landscape orientation is wider than tall.
+	Landscape
+)
+
+// The size class breakpoints.
+const (
+	mediumWidth    = unit.Dp(600)
+	expandedWidth  = unit.Dp(840)
+	mediumHeight   = unit.Dp(480)
+	expandedHeight = unit.Dp(900)
+)
+
+// WidthClass returns the size class of the maximum width constraint.
+func (c Context) WidthClass() SizeClass {
+	return sizeClass(c.Metric.PxToDp(c.Constraints.Max.X), mediumWidth, expandedWidth)
+}
+
+// HeightClass returns the size class of the maximum height
+// constraint.
+func (c Context) HeightClass() SizeClass {
+	return sizeClass(c.Metric.PxToDp(c.Constraints.Max.Y), mediumHeight, expandedHeight)
+}
+
+// Orientation returns the orientation of the maximum constraints.
+func (c Context) Orientation() Orientation {
+	if max := c.Constraints.Max; max.X > max.Y {
+		return Landscape
+	}
+	return Portrait
+}
+
+func sizeClass(v, medium, expanded unit.Dp) SizeClass {
+	switch {
+	case v >= expanded:
+		return SizeExpanded
+	case v >= medium:
+		return SizeMedium
+	default:
+		return SizeCompact
+	}
+}
+
+// Layout the last breakpoint that applies. If none applies, nothing is
+// laid out.
+func (r Responsive) Layout(gtx Context) Dimensions {
+	w := gtx.Metric.PxToDp(gtx.Constraints.Max.X)
+	h := gtx.Metric.PxToDp(gtx.Constraints.Max.Y)
+	o := gtx.Orientation()
+	for i := len(r) - 1; i >= 0; i-- {
+		b := r[i]
+		if w >= b.MinWidth && h >= b.MinHeight && (b.Orientation == AnyOrientation || b.Orientation == o) {
+			return b.Widget(gtx)
+		}
+	}
+	return Dimensions{}
+}
+
+// Layout the widget for the width class of gtx.
+func (s SizeClasses) Layout(gtx Context) Dimensions {
+	widgets := [...]Widget{s.Compact, s.Medium, s.Expanded}
+	for i := gtx.WidthClass(); ; i-- {
+		if w := widgets[i]; w != nil {
+			return w(gtx)
+		}
+		if i == SizeCompact {
+			return Dimensions{}
+		}
+	}
+}
+
+func (s SizeClass) String() string {
+	switch s {
+	case SizeCompact:
+		return "SizeCompact"
+	case SizeMedium:
+		return "SizeMedium"
+	case SizeExpanded:
+		return "SizeExpanded"
+	default:
+		panic("unreachable")
+	}
+}
+
+func (o Orientation) String() string {
+	switch o {
+	case AnyOrientation:
+		return "AnyOrientation"
+	case Portrait:
+		return "Portrait"
+	case Landscape:
+		return "Landscape"
+	default:
+		panic("unreachable")
+	}
+}
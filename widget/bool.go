@@ -3,6 +3,7 @@
 package widget
 
 import (
+	"gioui.org/animation"
 	"gioui.org/io/semantic"
 	"gioui.org/layout"
 )
@@ -10,7 +11,10 @@ import (
 type Bool struct {
 	Value bool
 
-	clk Clickable
+	clk   Clickable
+	value animation.Transition
+	// seeded tracks whether value started from Value.
+	seeded bool
 }
 
 // Update the widget state and report whether Value was changed.
@@ -38,6 +42,23 @@ func (b *Bool) Focused() bool {
 	return b.clk.Focused()
 }
 
+// HoverTransition returns the transition to the hovered or focused
+// state, for animating the presentation of b.
+func (b *Bool) HoverTransition() *animation.Transition {
+	return b.clk.HoverTransition()
+}
+
+// ValueTransition returns the transition to the true Value, for
+// animating the presentation of b.
+func (b *Bool) ValueTransition() *animation.Transition {
+	if !b.seeded {
+		// Start from Value instead of animating its initial value.
+		b.value.Jump(b.Value)
+		b.seeded = true
+	}
+	return &b.value
+}
+
 func (b *Bool) History() []Press {
 	return b.clk.History()
 }
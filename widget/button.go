@@ -6,6 +6,7 @@ import (
 	"image"
 	"time"
 
+	"gioui.org/animation"
 	"gioui.org/gesture"
 	"gioui.org/io/key"
 	"gioui.org/io/pointer"
@@ -17,6 +18,14 @@ import (
 
 // Clickable represents a clickable area.
 type Clickable struct {
+	// Buttons is the set of mouse buttons that click. If zero, only
+	// the primary button clicks.
+	Buttons pointer.Buttons
+	// LongPressDuration is the time a pointer must be pressed for the
+	// press to be reported as a long press click. If zero, long
+	// presses are not detected.
+	LongPressDuration time.Duration
+
 	click gesture.Click
 	// clicks is for saved clicks to support Clicked.
 	clicks  []Click
@@ -27,12 +36,20 @@ type Clickable struct {
 	requestClicks int
 	focused       bool
 	pressedKey    string
+	hover         animation.Transition
 }
 
 // Click represents a click.
 type Click struct {
 	Modifiers key.Modifiers
 	NumClicks int
+	// Button is the mouse button of the click. Clicks by touch,
+	// keyboard and Click are reported as primary button clicks.
+	Button pointer.Buttons
+	// LongPress reports whether the click is a long press. A long
+	// press is reported while the pointer is still pressed, and
+	// its release is not reported as a click.
+	LongPress bool
 }
 
 // Press represents a past pointer press.
@@ -88,6 +105,12 @@ func (b *Clickable) Focused() bool {
 	return b.focused
 }
 
+// HoverTransition returns the transition to the hovered or focused
+// state, for animating the presentation of b.
+func (b *Clickable) HoverTransition() *animation.Transition {
+	return &b.hover
+}
+
 // History is the past pointer presses useful for drawing markers.
 // History is retained for a short duration (about a second).
 func (b *Clickable) History() []Press {
@@ -139,8 +162,11 @@ func (b *Clickable) Update(gtx layout.Context) []Click {
 		b.requestClicks = 0
 		clicks = append(clicks, Click{
 			NumClicks: c,
+			Button:    pointer.ButtonPrimary,
 		})
 	}
+	b.click.Buttons = b.Buttons
+	b.click.LongPressDuration = b.LongPressDuration
 	for _, e := range b.click.Update(gtx) {
 		switch e.Kind {
 		case gesture.KindClick:
@@ -150,6 +176,7 @@ func (b *Clickable) Update(gtx layout.Context) []Click {
 			clicks = append(clicks, Click{
 				Modifiers: e.Modifiers,
 				NumClicks: e.NumClicks,
+				Button:    e.Button,
 			})
 		case gesture.KindCancel:
 			for i := range b.history {
@@ -168,6 +195,14 @@ func (b *Clickable) Update(gtx layout.Context) []Click {
 			})
 		}
 	}
+	if e, ok := b.click.LongPress(gtx.Metric, gtx.Ops, gtx.Now); ok {
+		clicks = append(clicks, Click{
+			Modifiers: e.Modifiers,
+			NumClicks: e.NumClicks,
+			Button:    e.Button,
+			LongPress: true,
+		})
+	}
 	for _, e := range gtx.Events(&b.keyTag) {
 		switch e := e.(type) {
 		case key.FocusEvent:
@@ -194,6 +229,7 @@ func (b *Clickable) Update(gtx layout.Context) []Click {
 				clicks = append(clicks, Click{
 					Modifiers: e.Modifiers,
 					NumClicks: 1,
+					Button:    pointer.ButtonPrimary,
 				})
 			}
 		}
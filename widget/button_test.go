@@ -5,8 +5,11 @@ package widget_test
 import (
 	"image"
 	"testing"
+	"time"
 
+	"gioui.org/f32"
 	"gioui.org/io/key"
+	"gioui.org/io/pointer"
 	"gioui.org/io/router"
 	"gioui.org/io/system"
 	"gioui.org/layout"
@@ -102,3 +105,45 @@ func TestClickable(t *testing.T) {
 		t.Error("button 2 should not have been clicked, as it only got return release")
 	}
 }
+
+func TestClickableButtons(t *testing.T) {
+	var (
+		ops op.Ops
+		r   router.Router
+	)
+	b := widget.Clickable{
+		Buttons:           pointer.ButtonPrimary | pointer.ButtonSecondary,
+		LongPressDuration: 500 * time.Millisecond,
+	}
+	now := time.Now()
+	gtx := layout.NewContext(&ops, system.FrameEvent{Queue: &r, Now: now})
+	frame := func() []widget.Click {
+		clicks := b.Update(gtx)
+		ops.Reset()
+		b.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			return layout.Dimensions{Size: image.Pt(100, 100)}
+		})
+		r.Frame(gtx.Ops)
+		return clicks
+	}
+	frame()
+	r.Queue(
+		pointer.Event{Kind: pointer.Press, Source: pointer.Mouse, Buttons: pointer.ButtonSecondary, Position: f32.Pt(50, 50)},
+		pointer.Event{Kind: pointer.Release, Source: pointer.Mouse, Position: f32.Pt(50, 50)},
+	)
+	if c := frame(); len(c) != 1 || c[0].Button != pointer.ButtonSecondary || c[0].LongPress {
+		t.Fatalf("got clicks %+v, want one secondary click", c)
+	}
+	r.Queue(pointer.Event{Kind: pointer.Press, Source: pointer.Touch, Position: f32.Pt(50, 50)})
+	if c := frame(); len(c) != 0 {
+		t.Fatalf("got clicks %+v on press", c)
+	}
+	gtx.Now = now.Add(time.Second)
+	if c := frame(); len(c) != 1 || !c[0].LongPress {
+		t.Fatalf("got clicks %+v, want a long press", c)
+	}
+	r.Queue(pointer.Event{Kind: pointer.Release, Source: pointer.Touch, Position: f32.Pt(50, 50)})
+	if c := frame(); len(c) != 0 {
+		t.Errorf("got clicks %+v after long press", c)
+	}
+}
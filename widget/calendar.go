@@ -0,0 +1,422 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget
+
+import (
+	"image"
+	"strings"
+	"time"
+
+	"gioui.org/gesture"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/io/semantic"
+	"gioui.org/io/system"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+)
+
+// Calendar holds the state of a month grid for picking a date or a
+// range of dates. Only the year, month and day of dates are used, and
+// the dates reported by Calendar are at midnight UTC.
+//
+// The weeks of the grid start on the first day of the week of the
+// locale, and the grid is mirrored for right-to-left locales. When the
+// grid has the keyboard focus, the arrow keys move the focused day,
+// Page Up and Page Down change the month, Home and End move to the
+// start and end of the week, and Enter or Space picks the focused day.
+type Calendar struct {
+	// Selected is the selected date, or the start of the selected
+	// range. The zero time means no selection.
+	Selected time.Time
+	// End is the end of the selected range, if Range is set.
+	End time.Time
+	// Range enables picking a range of dates: the first pick selects
+	// the start, and the second the end.
+	Range bool
+	// Min and Max are the earliest and latest dates that can be
+	// picked. The zero time means no limit.
+	Min, Max time.Time
+	// PrevMonth and NextMonth are the states of buttons that show the
+	// previous and next months.
+	PrevMonth, NextMonth Clickable
+
+	// month is the first day of the shown month, and focus the focused
+	// day.
+	month time.Time
+	focus time.Time
+	click gesture.Click
+	// picking tracks whether the start of a range is picked and the
+	// end is pending.
+	picking bool
+	focused bool
+	changed bool
+	// cell is the size of the day cells, header the height of the
+	// weekday header, first the first day of the week and rtl the
+	// direction during the last Layout.
+	cell   image.Point
+	header int
+	first  time.Weekday
+	rtl    bool
+}
+
+// DayState describes the state of a day of a Calendar for laying it
+// out.
+type DayState struct {
+	// Selected reports whether the day is the selected date, or the
+	// start or end of the selected range.
+	Selected bool
+	// InRange reports whether the day is inside the selected range.
+	InRange bool
+	Today   bool
+	// Focused reports whether the day has the keyboard focus.
+	Focused bool
+	// Outside reports whether the day is in the previous or next
+	// month.
+	Outside bool
+	// Disabled reports whether the day is before Min or after Max.
+	Disabled bool
+}
+
+// DayWidget lays out the day date with its state.
+type DayWidget func(gtx layout.Context, date time.Time, state DayState) layout.Dimensions
+
+// WeekdayWidget lays out the header of the weekday column.
+type WeekdayWidget func(gtx layout.Context, day time.Weekday) layout.Dimensions
+
+// calendarWeeks is the number of weeks in the grid, enough for every
+// month.
+const calendarWeeks = 6
+
+var calendarKeys = key.Set("←|→|↑|↓|⇞|⇟|(Shift)-[⇞,⇟]|⇱|⇲|⏎|⌤|Space")
+
+// dateOf returns the date of t at midnight UTC.
+func dateOf(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Month returns the first day of the shown month.
+func (c *Calendar) Month() time.Time {
+	return c.month
+}
+
+// SetMonth shows the month of date.
+func (c *Calendar) SetMonth(date time.Time) {
+	y, m, _ := date.Date()
+	c.month = time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	if c.focus.IsZero() || !c.sameMonth(c.focus) {
+		c.focus = c.month
+	}
+}
+
+// Focused reports whether the calendar has the keyboard focus.
+func (c *Calendar) Focused() bool {
+	return c.focused
+}
+
+// Picking reports whether the start of a range has been picked and
+// the end is pending.
+func (c *Calendar) Picking() bool {
+	return c.picking
+}
+
+func (c *Calendar) sameMonth(date time.Time) bool {
+	y, m, _ := date.Date()
+	cy, cm, _ := c.month.Date()
+	return y == cy && m == cm
+}
+
+// Update the state and report whether the selection was changed by
+// user interaction.
+func (c *Calendar) Update(gtx layout.Context) bool {
+	if gtx.Queue == nil {
+		c.focused = false
+	}
+	c.init(gtx)
+	for c.PrevMonth.Clicked(gtx) {
+		c.SetMonth(c.month.AddDate(0, -1, 0))
+	}
+	for c.NextMonth.Clicked(gtx) {
+		c.SetMonth(c.month.AddDate(0, 1, 0))
+	}
+	for _, e := range c.click.Update(gtx) {
+		date, ok := c.dateAt(e.Position)
+		if !ok {
+			continue
+		}
+		switch e.Kind {
+		case gesture.KindPress:
+			if e.Source == pointer.Mouse {
+				key.FocusOp{Tag: c}.Add(gtx.Ops)
+			}
+		case gesture.KindClick:
+			if c.enabled(date) {
+				c.focus = date
+				c.pick(date)
+				if !c.sameMonth(date) {
+					c.SetMonth(date)
+				}
+			}
+		}
+	}
+	for _, e := range gtx.Events(c) {
+		switch e := e.(type) {
+		case key.FocusEvent:
+			c.focused = e.Focus
+		case key.Event:
+			if e.State == key.Press {
+				c.key(e)
+			}
+		}
+	}
+	changed := c.changed
+	c.changed = false
+	return changed
+}
+
+// init shows the month of the selection, or of today.
+func (c *Calendar) init(gtx layout.Context) {
+	if !c.month.IsZero() {
+		return
+	}
+	date := dateOf(c.Selected)
+	if date.IsZero() {
+		date = dateOf(gtx.Now)
+	}
+	c.SetMonth(date)
+	c.focus = date
+}
+
+func (c *Calendar) key(e key.Event) {
+	focus := c.focus
+	next := 1
+	if c.rtl {
+		next = -1
+	}
+	switch e.Name {
+	case key.NameLeftArrow:
+		focus = focus.AddDate(0, 0, -next)
+	case key.NameRightArrow:
+		focus = focus.AddDate(0, 0, next)
+	case key.NameUpArrow:
+		focus = focus.AddDate(0, 0, -7)
+	case key.NameDownArrow:
+		focus = focus.AddDate(0, 0, 7)
+	case key.NamePageUp, key.NamePageDown:
+		months := 1
+		if e.Modifiers.Contain(key.ModShift) {
+			months = 12
+		}
+		if e.Name == key.NamePageUp {
+			months = -months
+		}
+		focus = addMonths(focus, months)
+	case key.NameHome:
+		focus = focus.AddDate(0, 0, -c.column(focus))
+	case key.NameEnd:
+		focus = focus.AddDate(0, 0, 6-c.column(focus))
+	case key.NameReturn, key.NameEnter, key.NameSpace:
+		if c.enabled(focus) {
+			c.pick(focus)
+		}
+		return
+	}
+	c.focus = c.clamp(focus)
+	if !c.sameMonth(c.focus) {
+		c.SetMonth(c.focus)
+	}
+}
+
+// addMonths adds months to date, keeping the day inside the month.
+func addMonths(date time.Time, months int) time.Time {
+	y, m, d := date.Date()
+	first := time.Date(y, m+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	if last := first.AddDate(0, 1, -1).Day(); d > last {
+		d = last
+	}
+	return first.AddDate(0, 0, d-1)
+}
+
+// column returns the column of date in the week.
+func (c *Calendar) column(date time.Time) int {
+	return (int(date.Weekday()) - int(c.first) + 7) % 7
+}
+
+func (c *Calendar) clamp(date time.Time) time.Time {
+	if min := dateOf(c.Min); !min.IsZero() && date.Before(min) {
+		return min
+	}
+	if max := dateOf(c.Max); !max.IsZero() && date.After(max) {
+		return max
+	}
+	return date
+}
+
+func (c *Calendar) enabled(date time.Time) bool {
+	return c.clamp(date).Equal(date)
+}
+
+// pick the date as the selection, or as the start or end of the range.
+func (c *Calendar) pick(date time.Time) {
+	c.changed = true
+	if !c.Range {
+		c.Selected = date
+		return
+	}
+	if !c.picking {
+		c.Selected = date
+		c.End = time.Time{}
+		c.picking = true
+		return
+	}
+	c.picking = false
+	if start := dateOf(c.Selected); date.Before(start) {
+		c.Selected, c.End = date, start
+	} else {
+		c.End = date
+	}
+}
+
+// start returns the first day of the grid.
+func (c *Calendar) start() time.Time {
+	return c.month.AddDate(0, 0, -c.column(c.month))
+}
+
+// dateAt returns the date of the cell at pos.
+func (c *Calendar) dateAt(pos image.Point) (time.Time, bool) {
+	if c.cell.X <= 0 || c.cell.Y <= 0 {
+		return time.Time{}, false
+	}
+	pos.Y -= c.header
+	if pos.X < 0 || pos.Y < 0 {
+		return time.Time{}, false
+	}
+	col, row := pos.X/c.cell.X, pos.Y/c.cell.Y
+	if col >= 7 || row >= calendarWeeks {
+		return time.Time{}, false
+	}
+	if c.rtl {
+		col = 6 - col
+	}
+	return c.start().AddDate(0, 0, row*7+col), true
+}
+
+func (c *Calendar) state(gtx layout.Context, date time.Time) DayState {
+	sel, end := dateOf(c.Selected), dateOf(c.End)
+	s := DayState{
+		Selected: date.Equal(sel) || c.Range && date.Equal(end),
+		Today:    date.Equal(dateOf(gtx.Now)),
+		Focused:  c.focused && date.Equal(c.focus),
+		Outside:  !c.sameMonth(date),
+		Disabled: !c.enabled(date),
+	}
+	if c.Range && !sel.IsZero() && !end.IsZero() {
+		s.InRange = !date.Before(sel) && !date.After(end)
+	}
+	return s
+}
+
+// Layout the weekday header with the weekday widget, and the grid of
+// the weeks of the shown month with the day widget. All cells are as
+// large as the largest day.
+func (c *Calendar) Layout(gtx layout.Context, weekday WeekdayWidget, day DayWidget) layout.Dimensions {
+	c.Update(gtx)
+	c.first = firstWeekday(gtx.Locale)
+	c.rtl = gtx.Locale.Direction.Progression() == system.TowardOrigin
+	cgtx := gtx
+	cgtx.Constraints.Min = image.Point{}
+	cgtx.Constraints.Max.X /= 7
+	cgtx.Constraints.Max.Y /= calendarWeeks + 1
+
+	// Measure the cells.
+	var days [7 * calendarWeeks]op.CallOp
+	start := c.start()
+	cell := image.Point{}
+	for i := range days {
+		m := op.Record(gtx.Ops)
+		date := start.AddDate(0, 0, i)
+		dims := day(cgtx, date, c.state(gtx, date))
+		days[i] = m.Stop()
+		cell = maxPoint(cell, dims.Size)
+	}
+	var weekdays [7]op.CallOp
+	header := 0
+	for i := range weekdays {
+		m := op.Record(gtx.Ops)
+		dims := weekday(cgtx, time.Weekday((int(c.first)+i)%7))
+		weekdays[i] = m.Stop()
+		cell.X = max(cell.X, dims.Size.X)
+		header = max(header, dims.Size.Y)
+	}
+	c.cell, c.header = cell, header
+	// x returns the position of column col.
+	x := func(col int) int {
+		if c.rtl {
+			col = 6 - col
+		}
+		return col * cell.X
+	}
+	for i, w := range weekdays {
+		trans := op.Offset(image.Pt(x(i), 0)).Push(gtx.Ops)
+		w.Add(gtx.Ops)
+		trans.Pop()
+	}
+	size := image.Pt(7*cell.X, header+calendarWeeks*cell.Y)
+	defer clip.Rect{Min: image.Pt(0, header), Max: size}.Push(gtx.Ops).Pop()
+	semantic.EnabledOp(gtx.Queue != nil).Add(gtx.Ops)
+	c.click.Add(gtx.Ops)
+	if gtx.Queue != nil {
+		key.InputOp{Tag: c, Keys: calendarKeys}.Add(gtx.Ops)
+	}
+	for i, d := range days {
+		trans := op.Offset(image.Pt(x(i%7), header+i/7*cell.Y)).Push(gtx.Ops)
+		d.Add(gtx.Ops)
+		trans.Pop()
+	}
+	return layout.Dimensions{Size: size}
+}
+
+func maxPoint(a, b image.Point) image.Point {
+	return image.Pt(max(a.X, b.X), max(a.Y, b.Y))
+}
+
+// firstWeekday returns the first day of the week for the region of the
+// locale, or for the most common region of its language if it has no
+// region.
+func firstWeekday(l system.Locale) time.Weekday {
+	tags := strings.Split(strings.ReplaceAll(l.Language, "_", "-"), "-")
+	region := ""
+	for _, t := range tags[1:] {
+		if len(t) == 2 {
+			region = strings.ToUpper(t)
+			break
+		}
+	}
+	if region == "" {
+		switch strings.ToLower(tags[0]) {
+		case "en", "ja", "ko", "zh", "he", "hi", "th", "pt":
+			return time.Sunday
+		case "ar", "fa":
+			return time.Saturday
+		}
+		return time.Monday
+	}
+	switch region {
+	case "AG", "AS", "BD", "BR", "BS", "BT", "BW", "BZ", "CA", "CN", "CO",
+		"DM", "DO", "ET", "GT", "GU", "HK", "HN", "ID", "IL", "IN", "JM",
+		"JP", "KE", "KH", "KR", "LA", "MH", "MM", "MO", "MT", "MX", "MZ",
+		"NI", "NP", "PA", "PE", "PH", "PK", "PR", "PT", "PY", "SA", "SG",
+		"SV", "TH", "TT", "TW", "UM", "US", "VE", "VI", "WS", "YE", "ZA",
+		"ZW":
+		return time.Sunday
+	case "AE", "AF", "BH", "DJ", "DZ", "EG", "IQ", "IR", "JO", "KW", "LY",
+		"OM", "QA", "SD", "SY":
+		return time.Saturday
+	}
+	return time.Monday
+}
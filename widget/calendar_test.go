@@ -0,0 +1,201 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget_test
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"gioui.org/f32"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/io/router"
+	"gioui.org/io/system"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCalendarFirstWeekday(t *testing.T) {
+	tests := []struct {
+		lang  string
+		first time.Time
+	}{
+		{"en-US", date(2024, time.February, 25)},
+		{"de-DE", date(2024, time.February, 26)},
+		{"fr", date(2024, time.February, 26)},
+		{"ar-EG", date(2024, time.February, 24)},
+		{"ja", date(2024, time.February, 25)},
+	}
+	for _, test := range tests {
+		var ops op.Ops
+		gtx := layout.NewContext(&ops, system.FrameEvent{})
+		gtx.Locale = system.Locale{Language: test.lang}
+		c := &widget.Calendar{Selected: date(2024, time.March, 15)}
+		var first time.Time
+		c.Layout(gtx, calendarWeekday, func(gtx layout.Context, d time.Time, s widget.DayState) layout.Dimensions {
+			if first.IsZero() {
+				first = d
+			}
+			return calendarDay(gtx, d, s)
+		})
+		if !first.Equal(test.first) {
+			t.Errorf("%s: grid starts on %v, want %v", test.lang, first, test.first)
+		}
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	var (
+		ops op.Ops
+		r   router.Router
+	)
+	c := &widget.Calendar{Selected: date(2024, time.March, 15)}
+	gtx := layout.NewContext(&ops, system.FrameEvent{Queue: &r})
+	gtx.Locale = system.Locale{Language: "en-US", Direction: system.LTR}
+	gtx.Constraints = layout.Exact(image.Pt(200, 200))
+	frame := func() bool {
+		ops.Reset()
+		changed := c.Update(gtx)
+		c.Layout(gtx, calendarWeekday, calendarDay)
+		r.Frame(gtx.Ops)
+		return changed
+	}
+	// click the day in the column col and the row row.
+	click := func(col, row int) bool {
+		pos := f32.Pt(float32(col*10+5), float32(10+row*10+5))
+		r.Queue(
+			pointer.Event{Kind: pointer.Press, Source: pointer.Mouse, Buttons: pointer.ButtonPrimary, Position: pos},
+			pointer.Event{Kind: pointer.Release, Source: pointer.Mouse, Position: pos},
+		)
+		return frame()
+	}
+	press := func(name string) bool {
+		r.Queue(key.Event{Name: name, State: key.Press})
+		return frame()
+	}
+
+	frame()
+	if got := c.Month(); !got.Equal(date(2024, time.March, 1)) {
+		t.Fatalf("showing %v, want the month of the selection", got)
+	}
+	// The fourth week starts on March 17.
+	if !click(3, 3) || !c.Selected.Equal(date(2024, time.March, 20)) {
+		t.Fatalf("selected %v after a click, want March 20", c.Selected)
+	}
+	frame()
+	if !c.Focused() {
+		t.Fatal("calendar not focused by a click")
+	}
+	press(key.NameRightArrow)
+	press(key.NameDownArrow)
+	if !press(key.NameReturn) || !c.Selected.Equal(date(2024, time.March, 28)) {
+		t.Errorf("selected %v with the keyboard, want March 28", c.Selected)
+	}
+	press(key.NamePageDown)
+	if got := c.Month(); !got.Equal(date(2024, time.April, 1)) {
+		t.Errorf("showing %v after Page Down, want April", got)
+	}
+	press(key.NamePageUp)
+
+	// The arrow keys follow the text direction.
+	gtx.Locale = system.Locale{Language: "ar-EG", Direction: system.RTL}
+	frame()
+	press(key.NameRightArrow)
+	press(key.NameReturn)
+	if !c.Selected.Equal(date(2024, time.March, 27)) {
+		t.Errorf("selected %v after the right arrow in a right-to-left locale, want March 27", c.Selected)
+	}
+	gtx.Locale = system.Locale{Language: "en-US", Direction: system.LTR}
+	frame()
+
+	// Pick a range backwards.
+	c.Range = true
+	click(0, 2)
+	if !c.Picking() {
+		t.Error("range start not picked")
+	}
+	click(5, 1)
+	if !c.Selected.Equal(date(2024, time.March, 8)) || !c.End.Equal(date(2024, time.March, 10)) {
+		t.Errorf("picked range %v to %v, want March 8 to 10", c.Selected, c.End)
+	}
+
+	// Days after Max can't be picked.
+	c.Range = false
+	c.Max = date(2024, time.March, 20)
+	if click(6, 4) {
+		t.Error("picked a day after Max")
+	}
+}
+
+func TestTimePicker(t *testing.T) {
+	var (
+		ops op.Ops
+		r   router.Router
+	)
+	p := &widget.TimePicker{Hour: 14, Minute: 5, Hour12: true, MinuteStep: 15}
+	gtx := layout.NewContext(&ops, system.FrameEvent{Queue: &r})
+	frame := func() bool {
+		ops.Reset()
+		changed := p.Update(gtx)
+		p.Layout(gtx, func(gtx layout.Context, s widget.TimeFieldState) layout.Dimensions {
+			return layout.Dimensions{Size: image.Pt(20, 20)}
+		}, func(gtx layout.Context) layout.Dimensions {
+			return layout.Dimensions{Size: image.Pt(10, 20)}
+		})
+		r.Frame(gtx.Ops)
+		return changed
+	}
+	edit := func(txt string) {
+		r.Queue(key.EditEvent{Text: txt})
+		frame()
+		frame()
+	}
+
+	frame()
+	if got := p.Text(widget.TimeHour); got != "02" {
+		t.Errorf("hour text %q, want 02", got)
+	}
+	r.Queue(
+		pointer.Event{Kind: pointer.Press, Source: pointer.Mouse, Buttons: pointer.ButtonPrimary, Position: f32.Pt(5, 5)},
+		pointer.Event{Kind: pointer.Release, Source: pointer.Mouse, Position: f32.Pt(5, 5)},
+	)
+	frame()
+	frame()
+	if f, ok := p.Focused(); !ok || f != widget.TimeHour {
+		t.Fatal("hour field not focused by a click")
+	}
+	// Typing 9 completes the hour and moves to the minutes.
+	edit("9")
+	if f, _ := p.Focused(); p.Hour != 21 || f != widget.TimeMinute {
+		t.Errorf("hour %d, focused field %d after typing 9, want 21 and the minutes", p.Hour, f)
+	}
+	edit("4")
+	edit("0")
+	if f, _ := p.Focused(); p.Minute != 40 || f != widget.TimePeriod {
+		t.Errorf("minute %d, focused field %d after typing 40, want 40 and the period", p.Minute, f)
+	}
+	edit("a")
+	if p.Hour != 9 {
+		t.Errorf("hour %d after typing a, want 9", p.Hour)
+	}
+	r.Queue(key.Event{Name: key.NameLeftArrow, State: key.Press})
+	frame()
+	r.Queue(key.Event{Name: key.NameUpArrow, State: key.Press})
+	if !frame() || p.Minute != 45 {
+		t.Errorf("minute %d after the up arrow, want the next step 45", p.Minute)
+	}
+}
+
+func calendarWeekday(gtx layout.Context, d time.Weekday) layout.Dimensions {
+	return layout.Dimensions{Size: image.Pt(10, 10)}
+}
+
+func calendarDay(gtx layout.Context, d time.Time, s widget.DayState) layout.Dimensions {
+	return layout.Dimensions{Size: image.Pt(10, 10)}
+}
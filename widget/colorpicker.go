@@ -0,0 +1,383 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"gioui.org/gesture"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+)
+
+// ColorPicker holds the state of a color picker made of a saturation and
+// value area, hue and alpha sliders, editors for the hexadecimal and RGB
+// values, and a row of recently picked colors.
+//
+// A color is added to Recent when a drag of the area or a slider ends,
+// and when an editor that changed the color loses the keyboard focus.
+type ColorPicker struct {
+	// Hue and Alpha are the sliders of the hue and alpha of the color.
+	Hue, Alpha Float
+	// Hex edits the color in the #rrggbb or #rrggbbaa format.
+	Hex Editor
+	// RGB edit the red, green and blue components from 0 to 255.
+	RGB [3]Editor
+	// Recent are the recently picked colors, most recent first.
+	Recent []color.NRGBA
+	// MaxRecent is the maximum length of Recent. If zero, a default
+	// length is used.
+	MaxRecent int
+
+	// h, s and v are the hue, saturation and value of the color, kept
+	// apart from col to preserve the hue of grays.
+	h, s, v float32
+	col     color.NRGBA
+	init    bool
+	area    gesture.Drag
+	size    image.Point
+	sliding bool
+	// texts are the editor texts during the last update, for detecting
+	// edits, and edited tracks whether an editor changed the color.
+	texts   [4]string
+	edited  bool
+	recent  []Clickable
+	changed bool
+}
+
+const defaultMaxRecent = 8
+
+// Color returns the picked color.
+func (p *ColorPicker) Color() color.NRGBA {
+	p.initColor()
+	return p.col
+}
+
+// SetColor sets the picked color, without adding it to Recent.
+func (p *ColorPicker) SetColor(c color.NRGBA) {
+	p.setRGB(c)
+	p.syncText(-1)
+}
+
+// setRGB sets the color and its HSV components.
+func (p *ColorPicker) setRGB(c color.NRGBA) {
+	p.init = true
+	p.col = c
+	h, s, v := rgbToHSV(c)
+	// Keep the hue of grays, and the hue and saturation of black.
+	if s > 0 && v > 0 {
+		p.h = h
+	}
+	if v > 0 {
+		p.s = s
+	}
+	p.v = v
+	p.Hue.Value = p.h / 360
+	p.Alpha.Value = float32(c.A) / 255
+}
+
+// HSV returns the hue in degrees, and the saturation and value from 0
+// to 1, of the picked color.
+func (p *ColorPicker) HSV() (h, s, v float32) {
+	p.initColor()
+	return p.h, p.s, p.v
+}
+
+// Dragging reports whether the area or a slider is being dragged.
+func (p *ColorPicker) Dragging() bool {
+	return p.area.Dragging() || p.Hue.Dragging() || p.Alpha.Dragging()
+}
+
+// AddRecent adds c to the front of Recent, removing any other copy of
+// it.
+func (p *ColorPicker) AddRecent(c color.NRGBA) {
+	n := p.MaxRecent
+	if n <= 0 {
+		n = defaultMaxRecent
+	}
+	recent := append([]color.NRGBA{c}, p.Recent...)
+	for i := 1; i < len(recent); i++ {
+		if recent[i] == c {
+			recent = append(recent[:i], recent[i+1:]...)
+			break
+		}
+	}
+	if len(recent) > n {
+		recent = recent[:n]
+	}
+	p.Recent = recent
+}
+
+// initColor initializes an unset color to opaque black.
+func (p *ColorPicker) initColor() {
+	if !p.init {
+		p.SetColor(color.NRGBA{A: 0xff})
+	}
+}
+
+// Update the state and report whether the color was changed by user
+// interaction.
+func (p *ColorPicker) Update(gtx layout.Context) bool {
+	p.initColor()
+	for _, e := range p.area.Update(gtx.Metric, gtx, gesture.Both) {
+		switch e.Kind {
+		case pointer.Press, pointer.Drag:
+			if p.size.X <= 0 || p.size.Y <= 0 {
+				break
+			}
+			s := clamp1(e.Position.X / float32(p.size.X))
+			v := clamp1(1 - e.Position.Y/float32(p.size.Y))
+			p.setHSV(p.h, s, v, p.col.A)
+		case pointer.Release, pointer.Cancel:
+			p.AddRecent(p.col)
+		}
+	}
+	if p.Hue.Update(gtx) {
+		p.setHSV(p.Hue.Value*360, p.s, p.v, p.col.A)
+	}
+	if p.Alpha.Update(gtx) {
+		if a := uint8(math.Round(float64(p.Alpha.Value) * 255)); a != p.col.A {
+			p.col.A = a
+			p.changed = true
+			p.syncText(-1)
+		}
+	}
+	sliding := p.Hue.Dragging() || p.Alpha.Dragging()
+	if p.sliding && !sliding {
+		p.AddRecent(p.col)
+	}
+	p.sliding = sliding
+	p.updateEditors()
+	for len(p.recent) < len(p.Recent) {
+		p.recent = append(p.recent, Clickable{})
+	}
+	for i := range p.Recent {
+		if p.recent[i].Clicked(gtx) {
+			p.SetColor(p.Recent[i])
+			p.changed = true
+		}
+	}
+	changed := p.changed
+	p.changed = false
+	return changed
+}
+
+// updateEditors parses the edited editor texts.
+func (p *ColorPicker) updateEditors() {
+	focused := false
+	for i := range p.texts {
+		e := p.editor(i)
+		focused = focused || e.Focused()
+		txt := e.Text()
+		if txt == p.texts[i] {
+			continue
+		}
+		p.texts[i] = txt
+		c := p.col
+		ok := false
+		if i == 0 {
+			c, ok = parseHexColor(txt, c.A)
+		} else if v, err := strconv.Atoi(strings.TrimSpace(txt)); err == nil && v >= 0 && v <= 255 {
+			switch i {
+			case 1:
+				c.R = uint8(v)
+			case 2:
+				c.G = uint8(v)
+			case 3:
+				c.B = uint8(v)
+			}
+			ok = true
+		}
+		if ok && c != p.col {
+			p.set(c, i)
+			p.edited = true
+		}
+	}
+	if p.edited && !focused {
+		p.edited = false
+		p.AddRecent(p.col)
+	}
+}
+
+// editor returns the hex editor for index 0, or an RGB editor.
+func (p *ColorPicker) editor(i int) *Editor {
+	if i == 0 {
+		return &p.Hex
+	}
+	return &p.RGB[i-1]
+}
+
+// set the color from its RGB components, and update the editors
+// except the editor with index except.
+func (p *ColorPicker) set(c color.NRGBA, except int) {
+	if c == p.col {
+		return
+	}
+	p.setRGB(c)
+	p.syncText(except)
+	p.changed = true
+}
+
+// setHSV sets the color from its HSV components, and updates the
+// editors.
+func (p *ColorPicker) setHSV(h, s, v float32, a uint8) {
+	p.h, p.s, p.v = h, s, v
+	p.Hue.Value = h / 360
+	c := hsvToRGB(h, s, v)
+	c.A = a
+	if c != p.col {
+		p.col = c
+		p.changed = true
+	}
+	p.syncText(-1)
+}
+
+// syncText formats the color into the editors, except the editor with
+// index except.
+func (p *ColorPicker) syncText(except int) {
+	c := p.col
+	texts := [4]string{
+		formatHexColor(c),
+		strconv.Itoa(int(c.R)),
+		strconv.Itoa(int(c.G)),
+		strconv.Itoa(int(c.B)),
+	}
+	for i, txt := range texts {
+		if i == except {
+			continue
+		}
+		if e := p.editor(i); e.Text() != txt {
+			e.SetText(txt)
+		}
+		p.texts[i] = txt
+	}
+}
+
+// LayoutArea lays out the input area for picking the saturation along
+// the horizontal axis and the value along the vertical axis. The area
+// has the minimum constraints size.
+func (p *ColorPicker) LayoutArea(gtx layout.Context) layout.Dimensions {
+	p.Update(gtx)
+	p.size = gtx.Constraints.Min
+	defer clip.Rect{Max: p.size}.Push(gtx.Ops).Pop()
+	p.area.Add(gtx.Ops)
+	pointer.CursorCrosshair.Add(gtx.Ops)
+	return layout.Dimensions{Size: p.size}
+}
+
+// LayoutRecent lays out the recent colors in a row with the swatch
+// widget. Clicking a recent color picks it.
+func (p *ColorPicker) LayoutRecent(gtx layout.Context, swatch func(gtx layout.Context, c color.NRGBA) layout.Dimensions) layout.Dimensions {
+	p.Update(gtx)
+	children := make([]layout.FlexChild, len(p.Recent))
+	for i := range p.Recent {
+		i := i
+		children[i] = layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return p.recent[i].Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return swatch(gtx, p.Recent[i])
+			})
+		})
+	}
+	return layout.Flex{}.Layout(gtx, children...)
+}
+
+func clamp1(v float32) float32 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
+
+// formatHexColor formats c as #rrggbb, or #rrggbbaa if c is not opaque.
+func formatHexColor(c color.NRGBA) string {
+	if c.A == 0xff {
+		return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A)
+}
+
+// parseHexColor parses a color in the #rgb, #rrggbb or #rrggbbaa format,
+// with an optional #. The alpha is a if the text has no alpha.
+func parseHexColor(txt string, a uint8) (color.NRGBA, bool) {
+	txt = strings.TrimPrefix(strings.TrimSpace(txt), "#")
+	if len(txt) == 3 {
+		txt = string([]byte{txt[0], txt[0], txt[1], txt[1], txt[2], txt[2]})
+	}
+	if len(txt) != 6 && len(txt) != 8 {
+		return color.NRGBA{}, false
+	}
+	v, err := strconv.ParseUint(txt, 16, 32)
+	if err != nil {
+		return color.NRGBA{}, false
+	}
+	if len(txt) == 6 {
+		v = v<<8 | uint64(a)
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, true
+}
+
+// hsvToRGB converts a hue in degrees, and a saturation and value from 0
+// to 1, to an opaque color.
+func hsvToRGB(h, s, v float32) color.NRGBA {
+	h = float32(math.Mod(float64(h), 360))
+	if h < 0 {
+		h += 360
+	}
+	c := v * s
+	x := c * (1 - float32(math.Abs(math.Mod(float64(h/60), 2)-1)))
+	m := v - c
+	var r, g, b float32
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	conv := func(v float32) uint8 {
+		return uint8(math.Round(float64(v+m) * 255))
+	}
+	return color.NRGBA{R: conv(r), G: conv(g), B: conv(b), A: 0xff}
+}
+
+// rgbToHSV converts the RGB components of c to a hue in degrees, and a
+// saturation and value from 0 to 1.
+func rgbToHSV(c color.NRGBA) (h, s, v float32) {
+	r, g, b := float32(c.R)/255, float32(c.G)/255, float32(c.B)/255
+	hi := float32(math.Max(float64(r), math.Max(float64(g), float64(b))))
+	lo := float32(math.Min(float64(r), math.Min(float64(g), float64(b))))
+	d := hi - lo
+	switch {
+	case d == 0:
+		h = 0
+	case hi == r:
+		h = 60 * float32(math.Mod(float64((g-b)/d), 6))
+	case hi == g:
+		h = 60 * ((b-r)/d + 2)
+	default:
+		h = 60 * ((r-g)/d + 4)
+	}
+	if h < 0 {
+		h += 360
+	}
+	if hi > 0 {
+		s = d / hi
+	}
+	return h, s, hi
+}
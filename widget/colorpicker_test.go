@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"gioui.org/f32"
+	"gioui.org/io/pointer"
+	"gioui.org/io/router"
+	"gioui.org/io/system"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+)
+
+func TestColorPicker(t *testing.T) {
+	var (
+		ops op.Ops
+		r   router.Router
+		p   widget.ColorPicker
+	)
+	gtx := layout.NewContext(&ops, system.FrameEvent{Queue: &r})
+	gtx.Constraints = layout.Exact(image.Pt(100, 100))
+	frame := func() bool {
+		ops.Reset()
+		changed := p.Update(gtx)
+		p.LayoutArea(gtx)
+		r.Frame(gtx.Ops)
+		return changed
+	}
+
+	p.SetColor(color.NRGBA{R: 0xff, A: 0xff})
+	if h, s, v := p.HSV(); h != 0 || s != 1 || v != 1 {
+		t.Errorf("HSV of red is %v, %v, %v", h, s, v)
+	}
+	if got := p.Hex.Text(); got != "#ff0000" {
+		t.Errorf("hex text %q, want #ff0000", got)
+	}
+	frame()
+
+	// Drag in the area to pick the saturation and value.
+	r.Queue(
+		pointer.Event{Kind: pointer.Press, Source: pointer.Mouse, Buttons: pointer.ButtonPrimary, Position: f32.Pt(50, 25)},
+		pointer.Event{Kind: pointer.Release, Source: pointer.Mouse, Position: f32.Pt(50, 25)},
+	)
+	if !frame() {
+		t.Error("no change from the area")
+	}
+	want := color.NRGBA{R: 191, G: 96, B: 96, A: 0xff}
+	if got := p.Color(); got != want {
+		t.Errorf("picked %v from the area, want %v", got, want)
+	}
+	if len(p.Recent) != 1 || p.Recent[0] != want {
+		t.Errorf("recent colors %v after a drag, want %v", p.Recent, want)
+	}
+	if got := p.RGB[0].Text(); got != "191" {
+		t.Errorf("red text %q, want 191", got)
+	}
+
+	// Enter a translucent color.
+	p.Hex.SetText("#00ff0080")
+	if !frame() {
+		t.Error("no change from the hex editor")
+	}
+	want = color.NRGBA{G: 0xff, A: 0x80}
+	if got := p.Color(); got != want {
+		t.Errorf("entered %v, want %v", got, want)
+	}
+	if got := p.RGB[1].Text(); got != "255" {
+		t.Errorf("green text %q, want 255", got)
+	}
+	if a := p.Alpha.Value; a < .5 || a > .51 {
+		t.Errorf("alpha slider at %v, want 0.5", a)
+	}
+	p.RGB[2].SetText("300")
+	if frame() {
+		t.Error("invalid blue component changed the color")
+	}
+
+	// Grays keep the hue.
+	p.SetColor(color.NRGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff})
+	if h, s, _ := p.HSV(); h != 120 || s != 0 {
+		t.Errorf("hue and saturation of gray are %v, %v, want 120, 0", h, s)
+	}
+}
@@ -0,0 +1,615 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget
+
+import (
+	"image"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gioui.org/f32"
+	"gioui.org/gesture"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/io/semantic"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/unit"
+)
+
+// Dropdown holds the state of a dropdown, a field showing the selected
+// option that opens a popup list of options when it is pressed. When
+// the field has the keyboard focus, the arrow keys, Enter and Space open
+// the list and move through it, and typing the start of an option
+// selects it.
+type Dropdown struct {
+	// Options are the option labels, used for type-ahead search.
+	Options []string
+	// Selected is the index of the selected option, or -1 for none.
+	Selected int
+	// MaxHeight is the maximum height of the option list. If zero, the
+	// list is only limited by Bounds.
+	MaxHeight unit.Dp
+	// Bounds is the window area relative to the field, that is the
+	// window rectangle offset by the negated position of the field.
+	// The option list is kept inside it. Callers must set Bounds for
+	// the list to stay inside the window: if empty, the list is kept
+	// inside the maximum constraints of the field instead.
+	Bounds image.Rectangle
+
+	click        gesture.Click
+	focused      bool
+	requestFocus bool
+	changed      bool
+	search       typeAhead
+	popup        optionList
+}
+
+// Combo holds the state of a combo box, an editor with a popup list of
+// the options that start with its text. The list opens while typing,
+// or with the down arrow key. The arrow and page keys move through the
+// list, and Enter or a click replaces the editor text with the option.
+type Combo struct {
+	Editor Editor
+	// Options are the option labels.
+	Options []string
+	// MaxHeight is the maximum height of the option list. If zero, the
+	// list is only limited by Bounds.
+	MaxHeight unit.Dp
+	// Bounds is the window area relative to the field, that is the
+	// window rectangle offset by the negated position of the field.
+	// The option list is kept inside it. Callers must set Bounds for
+	// the list to stay inside the window: if empty, the list is kept
+	// inside the maximum constraints of the field instead.
+	Bounds image.Rectangle
+
+	// text is the editor text during the last update, for detecting
+	// edits.
+	text string
+	// matches are the indices of the listed options.
+	matches []int
+	chosen  bool
+	// size is the size of the field during the last Layout.
+	size  image.Point
+	popup optionList
+}
+
+// OptionState describes the state of a listed option for laying it
+// out.
+type OptionState struct {
+	Selected bool
+	// Focused reports whether the option is highlighted by the
+	// pointer or the keyboard.
+	Focused bool
+}
+
+// OptionWidget lays out the option at index with its state.
+type OptionWidget func(gtx layout.Context, index int, state OptionState) layout.Dimensions
+
+// optionList is the popup list of a Dropdown or Combo.
+type optionList struct {
+	list layout.List
+	open bool
+	// focus is the index in the list of the highlighted option, or -1.
+	focus int
+	// scroll tracks whether focus should be scrolled into view during
+	// the next layout.
+	scroll bool
+	// rows are the options laid out during the last layout, and
+	// rowsTag the tag of the pointer handler of the list.
+	rows    listRows
+	rowsTag bool
+	scrim   bool
+	// pressed tracks whether an option is pressed, and press the
+	// position of the press.
+	pressed bool
+	pid     pointer.ID
+	press   f32.Point
+}
+
+// typeAhead accumulates typed text for selecting options by the start
+// of their labels.
+type typeAhead struct {
+	text string
+	last time.Time
+}
+
+// typeAheadTimeout is the time after which typed text starts a new
+// search.
+const typeAheadTimeout = time.Second
+
+var (
+	dropdownKeys = key.Set("(Alt)-[↑,↓]|[⇞,⇟,⇱,⇲,⏎,⌤,Space,⎋]")
+	comboKeys    = key.Set("(Alt)-[↑,↓]|[⇞,⇟,⏎,⌤,⎋]")
+)
+
+// Open the option list.
+func (d *Dropdown) Open() {
+	d.popup.show(d.Selected)
+}
+
+// Close the option list.
+func (d *Dropdown) Close() {
+	d.popup.open = false
+}
+
+// Opened reports whether the option list is open.
+func (d *Dropdown) Opened() bool {
+	return d.popup.open
+}
+
+// Focus requests the keyboard focus for the dropdown.
+func (d *Dropdown) Focus() {
+	d.requestFocus = true
+}
+
+// Focused reports whether the dropdown has the keyboard focus.
+func (d *Dropdown) Focused() bool {
+	return d.focused
+}
+
+// Hovered reports whether a pointer is over the field.
+func (d *Dropdown) Hovered() bool {
+	return d.click.Hovered()
+}
+
+// Update the state and report whether Selected was changed by user
+// interaction.
+func (d *Dropdown) Update(gtx layout.Context) bool {
+	if gtx.Queue == nil {
+		d.focused = false
+		d.popup.open = false
+	}
+	for _, e := range d.click.Update(gtx) {
+		if e.Kind != gesture.KindPress {
+			continue
+		}
+		if e.Source == pointer.Mouse {
+			key.FocusOp{Tag: d}.Add(gtx.Ops)
+		}
+		d.Open()
+	}
+	if i, ok := d.popup.update(gtx, len(d.Options)); ok {
+		d.selectOption(i)
+		d.popup.open = false
+	}
+	for _, e := range gtx.Events(d) {
+		switch e := e.(type) {
+		case key.FocusEvent:
+			if d.focused && !e.Focus {
+				d.popup.open = false
+			}
+			d.focused = e.Focus
+		case key.Event:
+			if e.State == key.Press {
+				d.key(e)
+			}
+		case key.EditEvent:
+			if i, ok := d.search.find(gtx.Now, e.Text, d.Options, d.current()); ok {
+				if d.popup.open {
+					d.popup.focusOption(i)
+				} else {
+					d.selectOption(i)
+				}
+			}
+		}
+	}
+	changed := d.changed
+	d.changed = false
+	return changed
+}
+
+// current returns the index of the highlighted or selected option.
+func (d *Dropdown) current() int {
+	if d.popup.open {
+		return d.popup.focus
+	}
+	return d.Selected
+}
+
+func (d *Dropdown) selectOption(i int) {
+	if i != d.Selected {
+		d.Selected = i
+		d.changed = true
+	}
+}
+
+func (d *Dropdown) key(e key.Event) {
+	if !d.popup.open {
+		switch e.Name {
+		case key.NameUpArrow, key.NameDownArrow, key.NameReturn, key.NameEnter:
+			d.Open()
+		case key.NameSpace:
+			if d.search.text == "" {
+				d.Open()
+			}
+		}
+		return
+	}
+	switch e.Name {
+	case key.NameUpArrow, key.NameDownArrow:
+		if e.Modifiers.Contain(key.ModAlt) {
+			d.popup.open = false
+			break
+		}
+		d.popup.key(e.Name, len(d.Options))
+	case key.NameReturn, key.NameEnter, key.NameSpace:
+		if e.Name == key.NameSpace && d.search.text != "" {
+			break
+		}
+		if f := d.popup.focus; f >= 0 && f < len(d.Options) {
+			d.selectOption(f)
+		}
+		d.popup.open = false
+	case key.NameEscape:
+		d.popup.open = false
+	default:
+		d.popup.key(e.Name, len(d.Options))
+	}
+}
+
+// Layout the field widget, and the option list when it is open with the
+// background widget and the option widget for each option. The field
+// should present the selected option. The list is as wide as the field,
+// and drawn above other content with op.Defer below the field, or above
+// it if there is more room there, within Bounds.
+func (d *Dropdown) Layout(gtx layout.Context, field, background layout.Widget, option OptionWidget) layout.Dimensions {
+	d.Update(gtx)
+	m := op.Record(gtx.Ops)
+	dims := field(gtx)
+	call := m.Stop()
+	area := clip.Rect{Max: dims.Size}.Push(gtx.Ops)
+	enabled := gtx.Queue != nil
+	semantic.EnabledOp(enabled).Add(gtx.Ops)
+	d.click.Add(gtx.Ops)
+	pointer.CursorPointer.Add(gtx.Ops)
+	if enabled {
+		key.InputOp{Tag: d, Keys: dropdownKeys}.Add(gtx.Ops)
+	}
+	if d.requestFocus {
+		key.FocusOp{Tag: d}.Add(gtx.Ops)
+		d.requestFocus = false
+	}
+	call.Add(gtx.Ops)
+	area.Pop()
+	if d.popup.open {
+		anchor := image.Rectangle{Max: dims.Size}
+		d.popup.layout(gtx, false, anchor, popupBounds(gtx, d.Bounds), len(d.Options), gtx.Dp(d.MaxHeight), background, func(gtx layout.Context, i int, focus bool) layout.Dimensions {
+			return option(gtx, i, OptionState{Selected: i == d.Selected, Focused: focus})
+		})
+	}
+	return dims
+}
+
+// Open the option list, if any options start with the editor text.
+func (c *Combo) Open() {
+	c.match()
+	if len(c.matches) > 0 {
+		c.popup.show(-1)
+	}
+}
+
+// Close the option list.
+func (c *Combo) Close() {
+	c.popup.open = false
+}
+
+// Opened reports whether the option list is open.
+func (c *Combo) Opened() bool {
+	return c.popup.open
+}
+
+// Update the state and report whether the editor text was replaced by
+// an option chosen by user interaction.
+func (c *Combo) Update(gtx layout.Context) bool {
+	if gtx.Queue == nil {
+		c.popup.open = false
+	}
+	if txt := c.Editor.Text(); txt != c.text {
+		c.text = txt
+		c.popup.open = false
+		if c.Editor.Focused() {
+			c.Open()
+		}
+	}
+	if !c.Editor.Focused() {
+		c.popup.open = false
+	}
+	for _, e := range gtx.Events(&c.popup.scrim) {
+		// Presses on the field don't close the list.
+		e, ok := e.(pointer.Event)
+		if ok && e.Kind == pointer.Press && !e.Position.Round().In(image.Rectangle{Max: c.size}) {
+			c.popup.open = false
+		}
+	}
+	if i, ok := c.popup.update(gtx, len(c.matches)); ok {
+		c.choose(c.matches[i])
+	}
+	for _, e := range gtx.Events(c) {
+		e, ok := e.(key.Event)
+		if !ok || e.State != key.Press {
+			continue
+		}
+		switch e.Name {
+		case key.NameUpArrow, key.NameDownArrow:
+			switch {
+			case e.Modifiers.Contain(key.ModAlt) && e.Name == key.NameUpArrow:
+				c.popup.open = false
+			case !c.popup.open:
+				if e.Name == key.NameDownArrow {
+					c.Open()
+				}
+			default:
+				c.popup.key(e.Name, len(c.matches))
+			}
+		case key.NameReturn, key.NameEnter:
+			if f := c.popup.focus; c.popup.open && f >= 0 && f < len(c.matches) {
+				c.choose(c.matches[f])
+			}
+		case key.NameEscape:
+			c.popup.open = false
+		default:
+			if c.popup.open {
+				c.popup.key(e.Name, len(c.matches))
+			}
+		}
+	}
+	chosen := c.chosen
+	c.chosen = false
+	return chosen
+}
+
+// match updates the listed options.
+func (c *Combo) match() {
+	c.matches = c.matches[:0]
+	prefix := strings.ToLower(c.Editor.Text())
+	for i, o := range c.Options {
+		if strings.HasPrefix(strings.ToLower(o), prefix) {
+			c.matches = append(c.matches, i)
+		}
+	}
+}
+
+// choose replaces the editor text with the option at index i.
+func (c *Combo) choose(i int) {
+	txt := c.Options[i]
+	c.Editor.SetText(txt)
+	n := utf8.RuneCountInString(txt)
+	c.Editor.SetCaret(n, n)
+	c.text = c.Editor.Text()
+	c.popup.open = false
+	c.chosen = true
+}
+
+// Layout the field widget, which should lay out the Editor, and the
+// option list when it is open, as described for Dropdown.Layout. The
+// option widget is passed the index of the option in Options.
+func (c *Combo) Layout(gtx layout.Context, field, background layout.Widget, option OptionWidget) layout.Dimensions {
+	c.Update(gtx)
+	c.Editor.combo = true
+	c.Editor.comboList = c.popup.open
+	m := op.Record(gtx.Ops)
+	dims := field(gtx)
+	call := m.Stop()
+	area := clip.Rect{Max: dims.Size}.Push(gtx.Ops)
+	if gtx.Queue != nil {
+		// The handler is an ancestor of the editor key handler, so
+		// it receives the keys the editor doesn't handle.
+		key.InputOp{Tag: c, Keys: comboKeys}.Add(gtx.Ops)
+	}
+	call.Add(gtx.Ops)
+	area.Pop()
+	c.size = dims.Size
+	if c.popup.open {
+		c.match()
+		txt := c.Editor.Text()
+		anchor := image.Rectangle{Max: dims.Size}
+		c.popup.layout(gtx, true, anchor, popupBounds(gtx, c.Bounds), len(c.matches), gtx.Dp(c.MaxHeight), background, func(gtx layout.Context, i int, focus bool) layout.Dimensions {
+			idx := c.matches[i]
+			return option(gtx, idx, OptionState{Selected: c.Options[idx] == txt, Focused: focus})
+		})
+	}
+	return dims
+}
+
+// show opens the list with the option at index focused.
+func (l *optionList) show(focus int) {
+	l.open = true
+	l.focus = focus
+	l.scroll = true
+	l.pressed = false
+}
+
+func (l *optionList) focusOption(i int) {
+	l.focus = i
+	l.scroll = true
+}
+
+// key moves the focus by a navigation key in a list of n options.
+func (l *optionList) key(name string, n int) {
+	if n == 0 {
+		return
+	}
+	page := l.list.Position.Count - 1
+	if page < 1 {
+		page = 1
+	}
+	f := l.focus
+	switch name {
+	case key.NameUpArrow:
+		f--
+	case key.NameDownArrow:
+		f++
+	case key.NamePageUp:
+		f -= page
+	case key.NamePageDown:
+		f += page
+	case key.NameHome:
+		f = 0
+	case key.NameEnd:
+		f = n - 1
+	default:
+		return
+	}
+	if l.focus < 0 && name == key.NameUpArrow {
+		f = n - 1
+	}
+	l.focusOption(clampInt(f, 0, n-1))
+}
+
+// update processes the pointer events of the list of n options, and
+// returns the index of a clicked option.
+func (l *optionList) update(gtx layout.Context, n int) (int, bool) {
+	for _, e := range gtx.Events(&l.scrim) {
+		if e, ok := e.(pointer.Event); ok && e.Kind == pointer.Press {
+			l.open = false
+		}
+	}
+	clicked, ok := 0, false
+	for _, e := range gtx.Events(&l.rowsTag) {
+		e, ok2 := e.(pointer.Event)
+		if !ok2 {
+			continue
+		}
+		switch e.Kind {
+		case pointer.Move:
+			if i, hit := l.rows.at(&l.list, e.Position.Y); hit {
+				l.focus = i
+			}
+		case pointer.Press:
+			if l.pressed {
+				break
+			}
+			if i, hit := l.rows.at(&l.list, e.Position.Y); hit {
+				l.focus = i
+				l.pressed = true
+				l.pid = e.PointerID
+				l.press = e.Position
+			}
+		case pointer.Release, pointer.Cancel:
+			if !l.pressed || e.PointerID != l.pid {
+				break
+			}
+			l.pressed = false
+			d := e.Position.Sub(l.press)
+			slop := float32(gtx.Dp(touchSlop))
+			if e.Kind == pointer.Cancel || d.X*d.X+d.Y*d.Y > slop*slop || l.list.Dragging() {
+				break
+			}
+			if i, hit := l.rows.at(&l.list, e.Position.Y); hit && i < n && l.open {
+				clicked, ok = i, true
+			}
+		}
+	}
+	if l.focus >= n {
+		l.focus = n - 1
+	}
+	return clicked, ok
+}
+
+// popupBounds returns bounds, or the maximum constraints of gtx if
+// bounds is empty.
+func popupBounds(gtx layout.Context, bounds image.Rectangle) image.Rectangle {
+	if bounds.Empty() {
+		return image.Rectangle{Max: gtx.Constraints.Max}
+	}
+	return bounds
+}
+
+// layout the list of n options next to anchor inside bounds, above other
+// content. The list blocks pointer input to the content beneath it,
+// unless pass is set. A maxHeight of zero means no limit.
+func (l *optionList) layout(gtx layout.Context, pass bool, anchor, bounds image.Rectangle, n, maxHeight int, background layout.Widget, option func(gtx layout.Context, i int, focus bool) layout.Dimensions) {
+	if l.scroll {
+		if l.focus >= 0 {
+			l.list.ScrollToAligned(l.focus, layout.ScrollNearest)
+		}
+		l.scroll = false
+	}
+	l.list.Axis = layout.Vertical
+	height := bounds.Max.Y - anchor.Max.Y
+	if above := anchor.Min.Y - bounds.Min.Y; above > height {
+		height = above
+	}
+	if maxHeight > 0 && height > maxHeight {
+		height = maxHeight
+	}
+	width := anchor.Dx()
+	lgtx := gtx
+	lgtx.Constraints = layout.Constraints{
+		Min: image.Pt(width, 0),
+		Max: image.Pt(width, max(height, 0)),
+	}
+
+	macro := op.Record(gtx.Ops)
+	if pass {
+		p := pointer.PassOp{}.Push(gtx.Ops)
+		area := clip.Rect{Min: image.Pt(-inf, -inf), Max: image.Pt(inf, inf)}.Push(gtx.Ops)
+		pointer.InputOp{Tag: &l.scrim, Kinds: pointer.Press}.Add(gtx.Ops)
+		area.Pop()
+		p.Pop()
+	} else {
+		area := clip.Rect{Min: image.Pt(-inf, -inf), Max: image.Pt(inf, inf)}.Push(gtx.Ops)
+		pointer.InputOp{Tag: &l.scrim, Kinds: pointer.Press}.Add(gtx.Ops)
+		area.Pop()
+	}
+	l.rows.reset()
+	lmacro := op.Record(gtx.Ops)
+	dims := l.list.Layout(lgtx, n, func(gtx layout.Context, i int) layout.Dimensions {
+		gtx.Constraints.Min.X = width
+		dims := option(gtx, i, i == l.focus)
+		l.rows.add(i, dims.Size.Y)
+		return dims
+	})
+	list := lmacro.Stop()
+	size := dims.Size
+	off := menuOffset(anchor, size, bounds, false)
+	trans := op.Offset(off).Push(gtx.Ops)
+	area := clip.Rect{Max: size}.Push(gtx.Ops)
+	pointer.InputOp{
+		Tag:   &l.rowsTag,
+		Kinds: pointer.Move | pointer.Press | pointer.Release | pointer.Cancel,
+	}.Add(gtx.Ops)
+	pointer.CursorPointer.Add(gtx.Ops)
+	bgtx := gtx
+	bgtx.Constraints = layout.Exact(size)
+	background(bgtx)
+	list.Add(gtx.Ops)
+	area.Pop()
+	trans.Pop()
+	op.Defer(gtx.Ops, macro.Stop())
+}
+
+// find appends text to the search text, and returns the index of the
+// first option that starts with the search text, starting from current.
+// Repeating the first letter of an option cycles through the options
+// starting with it.
+func (t *typeAhead) find(now time.Time, text string, options []string, current int) (int, bool) {
+	if now.Sub(t.last) > typeAheadTimeout {
+		t.text = ""
+	}
+	t.last = now
+	if t.text == "" && strings.TrimSpace(text) == "" {
+		return 0, false
+	}
+	t.text += strings.ToLower(text)
+	search := t.text
+	start := current
+	if r, n := utf8.DecodeRuneInString(search); strings.Trim(search, string(r)) == "" {
+		// Cycle through the options starting with a repeated letter.
+		search = search[:n]
+		start++
+	}
+	if start < 0 {
+		start = 0
+	}
+	for i := range options {
+		idx := (start + i) % len(options)
+		if strings.HasPrefix(strings.ToLower(options[idx]), search) {
+			return idx, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget_test
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"gioui.org/f32"
+	"gioui.org/font"
+	"gioui.org/font/gofont"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/io/router"
+	"gioui.org/io/system"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/text"
+	"gioui.org/widget"
+)
+
+var fruits = []string{"Apple", "Banana", "Blueberry", "Cherry", "Date"}
+
+func TestDropdown(t *testing.T) {
+	var (
+		ops op.Ops
+		r   router.Router
+		d   = widget.Dropdown{Options: fruits}
+	)
+	gtx := layout.NewContext(&ops, system.FrameEvent{Queue: &r})
+	gtx.Constraints = layout.Constraints{Max: image.Pt(400, 400)}
+	gtx.Now = time.Unix(0, 0)
+	field := func(gtx layout.Context) layout.Dimensions {
+		return layout.Dimensions{Size: image.Pt(100, 20)}
+	}
+	bg := func(gtx layout.Context) layout.Dimensions {
+		return layout.Dimensions{Size: gtx.Constraints.Min}
+	}
+	option := func(gtx layout.Context, i int, s widget.OptionState) layout.Dimensions {
+		return layout.Dimensions{Size: image.Pt(gtx.Constraints.Min.X, 20)}
+	}
+	frame := func() bool {
+		ops.Reset()
+		changed := d.Update(gtx)
+		d.Layout(gtx, field, bg, option)
+		r.Frame(gtx.Ops)
+		return changed
+	}
+	click := func(y float32) {
+		r.Queue(
+			pointer.Event{Kind: pointer.Press, Source: pointer.Mouse, Buttons: pointer.ButtonPrimary, Position: f32.Pt(50, y)},
+			pointer.Event{Kind: pointer.Release, Source: pointer.Mouse, Position: f32.Pt(50, y)},
+		)
+	}
+	press := func(name string) bool {
+		r.Queue(key.Event{Name: name, State: key.Press})
+		return frame()
+	}
+
+	frame()
+	click(10)
+	frame()
+	frame()
+	if !d.Opened() || !d.Focused() {
+		t.Fatal("dropdown not opened and focused by a press")
+	}
+	// The list is placed below the field; click the third option.
+	click(20 + 2*20 + 10)
+	if !frame() || d.Selected != 2 || d.Opened() {
+		t.Fatalf("selected %d, open %v after clicking an option; want 2, closed", d.Selected, d.Opened())
+	}
+
+	press(key.NameDownArrow)
+	if !d.Opened() {
+		t.Fatal("dropdown not opened by the down arrow")
+	}
+	press(key.NameDownArrow)
+	if !press(key.NameReturn) || d.Selected != 3 {
+		t.Errorf("selected %d after the down arrow and Enter, want 3", d.Selected)
+	}
+
+	// Type-ahead selects the first option starting with the text, and
+	// cycles through options starting with a repeated letter.
+	for _, test := range []struct {
+		text    string
+		timeout bool
+		want    int
+	}{
+		{"b", false, 1},
+		{"b", false, 2},
+		{"b", false, 1},
+		{"b", true, 2},
+		{"a", false, 1},
+		{"a", true, 0},
+	} {
+		if test.timeout {
+			gtx.Now = gtx.Now.Add(2 * time.Second)
+		}
+		r.Queue(key.EditEvent{Text: test.text})
+		frame()
+		if d.Selected != test.want {
+			t.Errorf("selected %d after typing %q, want %d", d.Selected, test.text, test.want)
+		}
+		gtx.Now = gtx.Now.Add(100 * time.Millisecond)
+	}
+}
+
+func TestDropdownBounds(t *testing.T) {
+	var (
+		ops op.Ops
+		r   router.Router
+		d   = widget.Dropdown{Options: make([]string, 10000), Selected: 5}
+	)
+	// The field is at the bottom of a 400x400 window, inside a
+	// vertical list with unbounded height.
+	pos := image.Pt(0, 350)
+	d.Bounds = image.Rectangle{Max: image.Pt(400, 400)}.Sub(pos)
+	gtx := layout.NewContext(&ops, system.FrameEvent{Queue: &r})
+	gtx.Constraints = layout.Constraints{Max: image.Pt(400, 1e6)}
+	field := func(gtx layout.Context) layout.Dimensions {
+		return layout.Dimensions{Size: image.Pt(100, 20)}
+	}
+	bg := func(gtx layout.Context) layout.Dimensions {
+		return layout.Dimensions{Size: gtx.Constraints.Min}
+	}
+	layouts := 0
+	option := func(gtx layout.Context, i int, s widget.OptionState) layout.Dimensions {
+		layouts++
+		return layout.Dimensions{Size: image.Pt(gtx.Constraints.Min.X, 20)}
+	}
+	frame := func() {
+		ops.Reset()
+		layouts = 0
+		off := op.Offset(pos).Push(gtx.Ops)
+		d.Layout(gtx, field, bg, option)
+		off.Pop()
+		r.Frame(gtx.Ops)
+	}
+	frame()
+	d.Open()
+	frame()
+	if layouts > 30 {
+		t.Errorf("%d options laid out, want about the options that fit the window", layouts)
+	}
+	// The list opens above the field, where there is more room, and
+	// fills the window above it.
+	r.Queue(
+		pointer.Event{Kind: pointer.Press, Source: pointer.Mouse, Buttons: pointer.ButtonPrimary, Position: f32.Pt(50, 30)},
+		pointer.Event{Kind: pointer.Release, Source: pointer.Mouse, Position: f32.Pt(50, 30)},
+	)
+	frame()
+	if d.Selected != 1 {
+		t.Errorf("selected %d after clicking the second option above the field, want 1", d.Selected)
+	}
+}
+
+func TestCombo(t *testing.T) {
+	var (
+		ops op.Ops
+		r   router.Router
+		c   = widget.Combo{Options: fruits}
+	)
+	c.Editor.SingleLine = true
+	shaper := text.NewShaper(text.NoSystemFonts(), text.WithCollection(gofont.Collection()))
+	gtx := layout.NewContext(&ops, system.FrameEvent{Queue: &r})
+	gtx.Constraints = layout.Constraints{Max: image.Pt(400, 400)}
+	field := func(gtx layout.Context) layout.Dimensions {
+		gtx.Constraints = layout.Exact(image.Pt(100, 20))
+		return c.Editor.Layout(gtx, shaper, font.Font{}, 10, op.CallOp{}, op.CallOp{})
+	}
+	bg := func(gtx layout.Context) layout.Dimensions {
+		return layout.Dimensions{Size: gtx.Constraints.Min}
+	}
+	var listed []int
+	option := func(gtx layout.Context, i int, s widget.OptionState) layout.Dimensions {
+		listed = append(listed, i)
+		return layout.Dimensions{Size: image.Pt(gtx.Constraints.Min.X, 20)}
+	}
+	frame := func() bool {
+		ops.Reset()
+		listed = listed[:0]
+		chosen := c.Update(gtx)
+		c.Layout(gtx, field, bg, option)
+		r.Frame(gtx.Ops)
+		return chosen
+	}
+	press := func(name string) bool {
+		r.Queue(key.Event{Name: name, State: key.Press})
+		return frame()
+	}
+
+	c.Editor.Focus()
+	frame()
+	r.Queue(key.EditEvent{Text: "b"})
+	frame()
+	frame()
+	if !c.Opened() {
+		t.Fatal("combo not opened by typing")
+	}
+	if len(listed) != 2 || listed[0] != 1 || listed[1] != 2 {
+		t.Errorf("listed options %v, want [1 2]", listed)
+	}
+	press(key.NameDownArrow)
+	press(key.NameDownArrow)
+	if !press(key.NameReturn) {
+		t.Error("no option chosen by Enter")
+	}
+	if got := c.Editor.Text(); got != "Blueberry" || c.Opened() {
+		t.Errorf("text %q, open %v after choosing; want Blueberry, closed", got, c.Opened())
+	}
+	press(key.NameDownArrow)
+	if !c.Opened() {
+		t.Error("combo not opened by the down arrow")
+	}
+	press(key.NameEscape)
+	if c.Opened() {
+		t.Error("combo not closed by Escape")
+	}
+
+	// The page keys move through the open list.
+	c.Editor.SetText("b")
+	frame()
+	frame()
+	press(key.NamePageDown)
+	if !press(key.NameReturn) {
+		t.Error("no option chosen by Page Down and Enter")
+	}
+	if got := c.Editor.Text(); got != "Banana" {
+		t.Errorf("text %q after Page Down and Enter, want Banana", got)
+	}
+}
@@ -74,6 +74,12 @@ type Editor struct {
 	blinkStart   time.Time
 	focused      bool
 	requestFocus bool
+	// combo is set for the editor of a Combo, whose key handler
+	// receives the vertical arrow and enter keys instead, and
+	// comboList while the option list of the Combo is open, when its
+	// handler also receives the page keys.
+	combo     bool
+	comboList bool
 
 	// ime tracks the state relevant to input methods.
 	ime struct {
@@ -623,24 +629,42 @@ func (e *Editor) layout(gtx layout.Context, textMaterial, selectMaterial op.Call
 		const keyFilterNoRightDown = "(ShortAlt)-(Shift)-[←,↑]|(Shift)-[⏎,⌤]|(ShortAlt)-(Shift)-[⌫,⌦]|(Shift)-[⇞,⇟,⇱,⇲]|Short-[C,V,X,A]|Short-(Shift)-Z"
 		const keyFilterNoArrows = "(Shift)-[⏎,⌤]|(ShortAlt)-(Shift)-[⌫,⌦]|(Shift)-[⇞,⇟,⇱,⇲]|Short-[C,V,X,A]|Short-(Shift)-Z"
 		const keyFilterAllArrows = "(ShortAlt)-(Shift)-[←,→,↑,↓]|(Shift)-[⏎,⌤]|(ShortAlt)-(Shift)-[⌫,⌦]|(Shift)-[⇞,⇟,⇱,⇲]|Short-[C,V,X,A]|Short-(Shift)-Z"
+		// The filters of a Combo editor leave the vertical arrow and
+		// enter keys to the Combo, and the page keys while its list
+		// is open.
+		const comboKeyFilterNoLeft = "(ShortAlt)-(Shift)-→|(ShortAlt)-(Shift)-[⌫,⌦]|(Shift)-[⇞,⇟,⇱,⇲]|Short-[C,V,X,A]|Short-(Shift)-Z"
+		const comboKeyFilterNoRight = "(ShortAlt)-(Shift)-←|(ShortAlt)-(Shift)-[⌫,⌦]|(Shift)-[⇞,⇟,⇱,⇲]|Short-[C,V,X,A]|Short-(Shift)-Z"
+		const comboKeyFilterNoArrows = "(ShortAlt)-(Shift)-[⌫,⌦]|(Shift)-[⇞,⇟,⇱,⇲]|Short-[C,V,X,A]|Short-(Shift)-Z"
+		const comboKeyFilterAllArrows = "(ShortAlt)-(Shift)-[←,→]|(ShortAlt)-(Shift)-[⌫,⌦]|(Shift)-[⇞,⇟,⇱,⇲]|Short-[C,V,X,A]|Short-(Shift)-Z"
+		const comboListKeyFilterNoLeft = "(ShortAlt)-(Shift)-→|(ShortAlt)-(Shift)-[⌫,⌦]|(Shift)-[⇱,⇲]|Short-[C,V,X,A]|Short-(Shift)-Z"
+		const comboListKeyFilterNoRight = "(ShortAlt)-(Shift)-←|(ShortAlt)-(Shift)-[⌫,⌦]|(Shift)-[⇱,⇲]|Short-[C,V,X,A]|Short-(Shift)-Z"
+		const comboListKeyFilterNoArrows = "(ShortAlt)-(Shift)-[⌫,⌦]|(Shift)-[⇱,⇲]|Short-[C,V,X,A]|Short-(Shift)-Z"
+		const comboListKeyFilterAllArrows = "(ShortAlt)-(Shift)-[←,→]|(ShortAlt)-(Shift)-[⌫,⌦]|(Shift)-[⇱,⇲]|Short-[C,V,X,A]|Short-(Shift)-Z"
+		var noLeftUp, noRightDown, noArrows, allArrows key.Set = keyFilterNoLeftUp, keyFilterNoRightDown, keyFilterNoArrows, keyFilterAllArrows
+		switch {
+		case e.combo && e.comboList:
+			noLeftUp, noRightDown, noArrows, allArrows = comboListKeyFilterNoLeft, comboListKeyFilterNoRight, comboListKeyFilterNoArrows, comboListKeyFilterAllArrows
+		case e.combo:
+			noLeftUp, noRightDown, noArrows, allArrows = comboKeyFilterNoLeft, comboKeyFilterNoRight, comboKeyFilterNoArrows, comboKeyFilterAllArrows
+		}
 		caret, _ := e.text.Selection()
 		switch {
 		case caret == 0 && caret == e.text.Len():
-			keys = keyFilterNoArrows
+			keys = noArrows
 		case caret == 0:
 			if gtx.Locale.Direction.Progression() == system.FromOrigin {
-				keys = keyFilterNoLeftUp
+				keys = noLeftUp
 			} else {
-				keys = keyFilterNoRightDown
+				keys = noRightDown
 			}
 		case caret == e.text.Len():
 			if gtx.Locale.Direction.Progression() == system.FromOrigin {
-				keys = keyFilterNoRightDown
+				keys = noRightDown
 			} else {
-				keys = keyFilterNoLeftUp
+				keys = noLeftUp
 			}
 		default:
-			keys = keyFilterAllArrows
+			keys = allArrows
 		}
 	}
 	key.InputOp{Tag: &e.eventKey, Hint: e.InputHint, Keys: keys}.Add(gtx.Ops)
@@ -3,6 +3,7 @@
 package widget
 
 import (
+	"gioui.org/animation"
 	"gioui.org/gesture"
 	"gioui.org/io/key"
 	"gioui.org/io/pointer"
@@ -24,18 +25,24 @@ type Enum struct {
 }
 
 type enumKey struct {
-	key   string
-	click gesture.Click
-	tag   struct{}
+	key          string
+	click        gesture.Click
+	tag          struct{}
+	hover, value animation.Transition
 }
 
-func (e *Enum) index(k string) *enumKey {
+// state returns the state of the key k, adding it if necessary.
+func (e *Enum) state(k string) *enumKey {
 	for _, v := range e.keys {
 		if v.key == k {
 			return v
 		}
 	}
-	return nil
+	state := &enumKey{key: k}
+	// Start from Value instead of animating its initial value.
+	state.value.Jump(e.Value == k)
+	e.keys = append(e.keys, state)
+	return state
 }
 
 // Update the state and report whether Value has changed by user interaction.
@@ -100,6 +107,18 @@ func (e *Enum) Focused() (string, bool) {
 	return e.focus, e.focused
 }
 
+// HoverTransition returns the transition of the key k to the hovered or
+// focused state, for animating its presentation.
+func (e *Enum) HoverTransition(k string) *animation.Transition {
+	return &e.state(k).hover
+}
+
+// ValueTransition returns the transition of the key k to being the
+// Value, for animating its presentation.
+func (e *Enum) ValueTransition(k string) *animation.Transition {
+	return &e.state(k).value
+}
+
 // Layout adds the event handler for the key k.
 func (e *Enum) Layout(gtx layout.Context, k string, content layout.Widget) layout.Dimensions {
 	e.Update(gtx)
@@ -108,13 +127,7 @@ func (e *Enum) Layout(gtx layout.Context, k string, content layout.Widget) layou
 	c := m.Stop()
 	defer clip.Rect{Max: dims.Size}.Push(gtx.Ops).Pop()
 
-	state := e.index(k)
-	if state == nil {
-		state = &enumKey{
-			key: k,
-		}
-		e.keys = append(e.keys, state)
-	}
+	state := e.state(k)
 	clk := &state.click
 	clk.Add(gtx.Ops)
 	enabled := gtx.Queue != nil
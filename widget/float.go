@@ -5,6 +5,7 @@ package widget
 import (
 	"image"
 
+	"gioui.org/animation"
 	"gioui.org/gesture"
 	"gioui.org/io/pointer"
 	"gioui.org/layout"
@@ -20,11 +21,26 @@ type Float struct {
 	drag   gesture.Drag
 	axis   layout.Axis
 	length float32
+
+	pos      animation.Spring
+	dragging animation.Transition
 }
 
 // Dragging returns whether the value is being interacted with.
 func (f *Float) Dragging() bool { return f.drag.Dragging() }
 
+// PositionSpring returns a spring for animating the presented position
+// of the Value.
+func (f *Float) PositionSpring() *animation.Spring {
+	return &f.pos
+}
+
+// DragTransition returns the transition to the dragging state, for
+// animating the presentation of f.
+func (f *Float) DragTransition() *animation.Transition {
+	return &f.dragging
+}
+
 func (f *Float) Layout(gtx layout.Context, axis layout.Axis, pointerMargin unit.Dp) layout.Dimensions {
 	f.Update(gtx)
 	size := gtx.Constraints.Min
@@ -189,3 +189,46 @@ type List struct {
 	Scrollbar
 	layout.List
 }
+
+// listRows records the elements of a layout.List laid out during a
+// frame, for finding the element at a position.
+type listRows struct {
+	rows []listRow
+}
+
+type listRow struct {
+	index, size int
+}
+
+func (r *listRows) reset() {
+	r.rows = r.rows[:0]
+}
+
+// add records the element at index with the given size along the axis
+// of the list.
+func (r *listRows) add(index, size int) {
+	r.rows = append(r.rows, listRow{index: index, size: size})
+}
+
+// at returns the index of the visible element of l at the position pos
+// along its axis, in the coordinates of l.
+func (r *listRows) at(l *layout.List, pos float32) (int, bool) {
+	off := -l.Position.Offset
+	for i := l.Position.First; i < l.Position.First+l.Position.Count; i++ {
+		size := -1
+		for _, row := range r.rows {
+			if row.index == i {
+				size = row.size
+				break
+			}
+		}
+		if size < 0 {
+			break
+		}
+		if pos >= float32(off) && pos < float32(off+size) {
+			return i, true
+		}
+		off += size
+	}
+	return 0, false
+}
@@ -6,6 +6,7 @@ import (
 	"image"
 	"image/color"
 	"math"
+	"time"
 
 	"gioui.org/font"
 	"gioui.org/internal/f32color"
@@ -29,13 +30,25 @@ type ButtonStyle struct {
 	CornerRadius unit.Dp
 	Inset        layout.Inset
 	Button       *widget.Clickable
-	shaper       *text.Shaper
+	// Duration of hover animations. If zero, hovering is not
+	// animated.
+	Duration time.Duration
+	// ReducedMotion replaces the ink ripple of presses with a flat
+	// fill.
+	ReducedMotion bool
+	shaper        *text.Shaper
 }
 
 type ButtonLayoutStyle struct {
 	Background   color.NRGBA
 	CornerRadius unit.Dp
 	Button       *widget.Clickable
+	// Duration of hover animations. If zero, hovering is not
+	// animated.
+	Duration time.Duration
+	// ReducedMotion replaces the ink ripple of presses with a flat
+	// fill.
+	ReducedMotion bool
 }
 
 type IconButtonStyle struct {
@@ -48,6 +61,12 @@ type IconButtonStyle struct {
 	Inset       layout.Inset
 	Button      *widget.Clickable
 	Description string
+	// Duration of hover animations. If zero, hovering is not
+	// animated.
+	Duration time.Duration
+	// ReducedMotion replaces the ink ripple of presses with a flat
+	// fill.
+	ReducedMotion bool
 }
 
 func Button(th *Theme, button *widget.Clickable, txt string) ButtonStyle {
@@ -61,8 +80,10 @@ func Button(th *Theme, button *widget.Clickable, txt string) ButtonStyle {
 			Top: 10, Bottom: 10,
 			Left: 12, Right: 12,
 		},
-		Button: button,
-		shaper: th.Shaper,
+		Button:        button,
+		Duration:      th.motion(),
+		ReducedMotion: th.Motion.Reduced,
+		shaper:        th.Shaper,
 	}
 	b.Font.Typeface = th.Face
 	return b
@@ -70,21 +91,25 @@ func Button(th *Theme, button *widget.Clickable, txt string) ButtonStyle {
 
 func ButtonLayout(th *Theme, button *widget.Clickable) ButtonLayoutStyle {
 	return ButtonLayoutStyle{
-		Button:       button,
-		Background:   th.Palette.ContrastBg,
-		CornerRadius: 4,
+		Button:        button,
+		Background:    th.Palette.ContrastBg,
+		CornerRadius:  4,
+		Duration:      th.motion(),
+		ReducedMotion: th.Motion.Reduced,
 	}
 }
 
 func IconButton(th *Theme, button *widget.Clickable, icon *widget.Icon, description string) IconButtonStyle {
 	return IconButtonStyle{
-		Background:  th.Palette.ContrastBg,
-		Color:       th.Palette.ContrastFg,
-		Icon:        icon,
-		Size:        24,
-		Inset:       layout.UniformInset(12),
-		Button:      button,
-		Description: description,
+		Background:    th.Palette.ContrastBg,
+		Color:         th.Palette.ContrastFg,
+		Icon:          icon,
+		Size:          24,
+		Inset:         layout.UniformInset(12),
+		Button:        button,
+		Description:   description,
+		Duration:      th.motion(),
+		ReducedMotion: th.Motion.Reduced,
 	}
 }
 
@@ -111,9 +136,11 @@ func Clickable(gtx layout.Context, button *widget.Clickable, w layout.Widget) la
 
 func (b ButtonStyle) Layout(gtx layout.Context) layout.Dimensions {
 	return ButtonLayoutStyle{
-		Background:   b.Background,
-		CornerRadius: b.CornerRadius,
-		Button:       b.Button,
+		Background:    b.Background,
+		CornerRadius:  b.CornerRadius,
+		Button:        b.Button,
+		Duration:      b.Duration,
+		ReducedMotion: b.ReducedMotion,
 	}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 		return b.Inset.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 			colMacro := op.Record(gtx.Ops)
@@ -131,16 +158,18 @@ func (b ButtonLayoutStyle) Layout(gtx layout.Context, w layout.Widget) layout.Di
 			func(gtx layout.Context) layout.Dimensions {
 				rr := gtx.Dp(b.CornerRadius)
 				defer clip.UniformRRect(image.Rectangle{Max: gtx.Constraints.Min}, rr).Push(gtx.Ops).Pop()
-				background := b.Background
-				switch {
-				case gtx.Queue == nil:
+				hover := transition(gtx, b.Button.HoverTransition(), b.Duration, b.Button.Hovered() || b.Button.Focused())
+				background := f32color.Lerp(b.Background, f32color.Hovered(b.Background), hover)
+				if gtx.Queue == nil {
 					background = f32color.Disabled(b.Background)
-				case b.Button.Hovered() || b.Button.Focused():
-					background = f32color.Hovered(b.Background)
 				}
 				paint.Fill(gtx.Ops, background)
 				for _, c := range b.Button.History() {
-					drawInk(gtx, c)
+					if b.ReducedMotion {
+						drawPress(gtx, c)
+					} else {
+						drawInk(gtx, c)
+					}
 				}
 				return layout.Dimensions{Size: gtx.Constraints.Min}
 			},
@@ -163,16 +192,18 @@ func (b IconButtonStyle) Layout(gtx layout.Context) layout.Dimensions {
 			func(gtx layout.Context) layout.Dimensions {
 				rr := (gtx.Constraints.Min.X + gtx.Constraints.Min.Y) / 4
 				defer clip.UniformRRect(image.Rectangle{Max: gtx.Constraints.Min}, rr).Push(gtx.Ops).Pop()
-				background := b.Background
-				switch {
-				case gtx.Queue == nil:
+				hover := transition(gtx, b.Button.HoverTransition(), b.Duration, b.Button.Hovered() || b.Button.Focused())
+				background := f32color.Lerp(b.Background, f32color.Hovered(b.Background), hover)
+				if gtx.Queue == nil {
 					background = f32color.Disabled(b.Background)
-				case b.Button.Hovered() || b.Button.Focused():
-					background = f32color.Hovered(b.Background)
 				}
 				paint.Fill(gtx.Ops, background)
 				for _, c := range b.Button.History() {
-					drawInk(gtx, c)
+					if b.ReducedMotion {
+						drawPress(gtx, c)
+					} else {
+						drawInk(gtx, c)
+					}
 				}
 				return layout.Dimensions{Size: gtx.Constraints.Min}
 			},
@@ -197,6 +228,23 @@ func (b IconButtonStyle) Layout(gtx layout.Context) layout.Dimensions {
 	return dims
 }
 
+// drawPress draws the ink of a press without animation: the ink covers
+// the button until the press ends.
+func drawPress(gtx layout.Context, c widget.Press) {
+	if !c.End.IsZero() {
+		return
+	}
+	paint.Fill(gtx.Ops, inkColor(1))
+}
+
+// inkColor returns the color of ink faded in by t from 0 to 1.
+func inkColor(t float32) color.NRGBA {
+	alpha := 0.7 * t
+	const col = 0.8
+	ba, bc := byte(alpha*0xff), byte(col*0xff)
+	return f32color.MulAlpha(color.NRGBA{A: 0xff, R: bc, G: bc, B: bc}, ba)
+}
+
 func drawInk(gtx layout.Context, c widget.Press) {
 	// duration is the number of seconds for the
 	// completed animation: expand while fading in, then
@@ -281,11 +329,7 @@ func drawInk(gtx layout.Context, c widget.Press) {
 	// Cover the entire constraints min rectangle and
 	// apply curve values to size and color.
 	size = int(float32(size) * 2 * float32(math.Sqrt(2)) * sizeBezier)
-	alpha := 0.7 * alphaBezier
-	const col = 0.8
-	ba, bc := byte(alpha*0xff), byte(col*0xff)
-	rgba := f32color.MulAlpha(color.NRGBA{A: 0xff, R: bc, G: bc, B: bc}, ba)
-	ink := paint.ColorOp{Color: rgba}
+	ink := paint.ColorOp{Color: inkColor(alphaBezier)}
 	ink.Add(gtx.Ops)
 	rr := size / 2
 	defer op.Offset(c.Position.Add(image.Point{
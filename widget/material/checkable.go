@@ -5,6 +5,7 @@ package material
 import (
 	"image"
 	"image/color"
+	"time"
 
 	"gioui.org/font"
 	"gioui.org/internal/f32color"
@@ -18,25 +19,22 @@ import (
 )
 
 type checkable struct {
-	Label              string
-	Color              color.NRGBA
-	Font               font.Font
-	TextSize           unit.Sp
-	IconColor          color.NRGBA
-	Size               unit.Dp
+	Label     string
+	Color     color.NRGBA
+	Font      font.Font
+	TextSize  unit.Sp
+	IconColor color.NRGBA
+	Size      unit.Dp
+	// Duration of the check and hover animations.
+	Duration           time.Duration
 	shaper             *text.Shaper
 	checkedStateIcon   *widget.Icon
 	uncheckedStateIcon *widget.Icon
 }
 
-func (c *checkable) layout(gtx layout.Context, checked, hovered bool) layout.Dimensions {
-	var icon *widget.Icon
-	if checked {
-		icon = c.checkedStateIcon
-	} else {
-		icon = c.uncheckedStateIcon
-	}
-
+// layout the checkable, given the progress of the transitions to the
+// checked and hovered states.
+func (c *checkable) layout(gtx layout.Context, checked, hovered float32) layout.Dimensions {
 	dims := layout.Flex{Alignment: layout.Middle}.Layout(gtx,
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 			return layout.Stack{Alignment: layout.Center}.Layout(gtx,
@@ -45,11 +43,11 @@ func (c *checkable) layout(gtx layout.Context, checked, hovered bool) layout.Dim
 					dims := layout.Dimensions{
 						Size: image.Point{X: size, Y: size},
 					}
-					if !hovered {
+					if hovered == 0 {
 						return dims
 					}
 
-					background := f32color.MulAlpha(c.IconColor, 70)
+					background := f32color.MulAlpha(c.IconColor, uint8(70*hovered))
 
 					b := image.Rectangle{Max: image.Pt(size, size)}
 					paint.FillShape(gtx.Ops, background, clip.Ellipse(b).Op(gtx.Ops))
@@ -64,7 +62,13 @@ func (c *checkable) layout(gtx layout.Context, checked, hovered bool) layout.Dim
 							col = f32color.Disabled(col)
 						}
 						gtx.Constraints.Min = image.Point{X: size}
-						icon.Layout(gtx, col)
+						// Cross-fade the icons.
+						if checked < 1 {
+							c.uncheckedStateIcon.Layout(gtx, f32color.MulAlpha(col, uint8(0xff*(1-checked))))
+						}
+						if checked > 0 {
+							c.checkedStateIcon.Layout(gtx, f32color.MulAlpha(col, uint8(0xff*checked)))
+						}
 						return layout.Dimensions{
 							Size: image.Point{X: size, Y: size},
 						}
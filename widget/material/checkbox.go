@@ -22,6 +22,7 @@ func CheckBox(th *Theme, checkBox *widget.Bool, label string) CheckBoxStyle {
 			IconColor:          th.Palette.ContrastBg,
 			TextSize:           th.TextSize * 14.0 / 16.0,
 			Size:               26,
+			Duration:           th.motion(),
 			shaper:             th.Shaper,
 			checkedStateIcon:   th.Icon.CheckBoxChecked,
 			uncheckedStateIcon: th.Icon.CheckBoxUnchecked,
@@ -35,6 +36,8 @@ func CheckBox(th *Theme, checkBox *widget.Bool, label string) CheckBoxStyle {
 func (c CheckBoxStyle) Layout(gtx layout.Context) layout.Dimensions {
 	return c.CheckBox.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 		semantic.CheckBox.Add(gtx.Ops)
-		return c.layout(gtx, c.CheckBox.Value, c.CheckBox.Hovered() || c.CheckBox.Focused())
+		checked := transition(gtx, c.CheckBox.ValueTransition(), c.Duration, c.CheckBox.Value)
+		hovered := transition(gtx, c.CheckBox.HoverTransition(), c.Duration, c.CheckBox.Hovered() || c.CheckBox.Focused())
+		return c.layout(gtx, checked, hovered)
 	})
 }
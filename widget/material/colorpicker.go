@@ -0,0 +1,294 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package material
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/f32"
+	"gioui.org/internal/f32color"
+	"gioui.org/io/semantic"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+)
+
+// ColorPickerStyle configures the presentation of a color picker.
+type ColorPickerStyle struct {
+	Picker *widget.ColorPicker
+	// Hex and RGB are the styles of the editors of the picker.
+	Hex EditorStyle
+	RGB [3]EditorStyle
+	// Width is the width of the picker.
+	Width unit.Dp
+	// AreaHeight is the height of the saturation and value area, and
+	// SliderHeight the height of the hue and alpha sliders.
+	AreaHeight   unit.Dp
+	SliderHeight unit.Dp
+	// SwatchSize is the size of the preview and recent colors.
+	SwatchSize   unit.Dp
+	CornerRadius unit.Dp
+	// Spacing is the space between the parts of the picker.
+	Spacing unit.Dp
+	// BorderColor is the color of the outlines of editors and swatches,
+	// and ThumbColor the color of the area and slider thumbs.
+	BorderColor color.NRGBA
+	ThumbColor  color.NRGBA
+	// CheckerColor is the color of the checkerboard behind translucent
+	// colors.
+	CheckerColor color.NRGBA
+}
+
+func ColorPicker(th *Theme, picker *widget.ColorPicker) ColorPickerStyle {
+	p := ColorPickerStyle{
+		Picker:       picker,
+		Hex:          Editor(th, &picker.Hex, "#rrggbb"),
+		Width:        256,
+		AreaHeight:   160,
+		SliderHeight: 12,
+		SwatchSize:   24,
+		CornerRadius: 4,
+		Spacing:      8,
+		BorderColor:  f32color.MulAlpha(th.Palette.Fg, 0x66),
+		ThumbColor:   color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		CheckerColor: color.NRGBA{R: 0xcc, G: 0xcc, B: 0xcc, A: 0xff},
+	}
+	for i, hint := range []string{"R", "G", "B"} {
+		p.RGB[i] = Editor(th, &picker.RGB[i], hint)
+	}
+	p.Hex.TextSize = th.TextSize * 14.0 / 16.0
+	for i := range p.RGB {
+		p.RGB[i].TextSize = p.Hex.TextSize
+	}
+	return p
+}
+
+// Layout the saturation and value area above the hue and alpha sliders
+// with a preview of the color, the editors and the recent colors.
+func (p ColorPickerStyle) Layout(gtx layout.Context) layout.Dimensions {
+	p.Picker.Hex.SingleLine = true
+	for i := range p.Picker.RGB {
+		p.Picker.RGB[i].SingleLine = true
+	}
+	p.Picker.Update(gtx)
+	gtx.Constraints.Min = image.Point{}
+	gtx.Constraints.Max.X = min(gtx.Dp(p.Width), gtx.Constraints.Max.X)
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	space := layout.Spacer{Height: p.Spacing}
+	children := []layout.FlexChild{
+		layout.Rigid(p.area),
+		layout.Rigid(space.Layout),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							return p.slider(gtx, &p.Picker.Hue, p.hueTrack)
+						}),
+						layout.Rigid(space.Layout),
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							return p.slider(gtx, &p.Picker.Alpha, p.alphaTrack)
+						}),
+					)
+				}),
+				layout.Rigid(layout.Spacer{Width: p.Spacing}.Layout),
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					size := gtx.Dp(p.SwatchSize * 3 / 2)
+					return p.swatch(gtx, p.Picker.Color(), image.Pt(size, size))
+				}),
+			)
+		}),
+		layout.Rigid(space.Layout),
+		layout.Rigid(p.editors),
+	}
+	if len(p.Picker.Recent) > 0 {
+		children = append(children,
+			layout.Rigid(space.Layout),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return p.Picker.LayoutRecent(gtx, func(gtx layout.Context, c color.NRGBA) layout.Dimensions {
+					semantic.Button.Add(gtx.Ops)
+					size := gtx.Dp(p.SwatchSize)
+					return layout.Inset{Right: p.Spacing / 2}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+						return p.swatch(gtx, c, image.Pt(size, size))
+					})
+				})
+			}),
+		)
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+// area draws the saturation and value gradients of the hue, and the thumb
+// at the picked saturation and value.
+func (p ColorPickerStyle) area(gtx layout.Context) layout.Dimensions {
+	size := image.Pt(gtx.Constraints.Max.X, gtx.Dp(p.AreaHeight))
+	h, s, v := p.Picker.HSV()
+	hue := hueColor(h)
+	r := image.Rectangle{Max: size}
+	area := clip.UniformRRect(r, gtx.Dp(p.CornerRadius)).Push(gtx.Ops)
+	paint.Fill(gtx.Ops, hue)
+	white := color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	black := color.NRGBA{A: 0xff}
+	fsize := layout.FPt(size)
+	paint.LinearGradientOp{
+		Stop1: f32.Pt(0, 0), Color1: white,
+		Stop2: f32.Pt(fsize.X, 0), Color2: f32color.MulAlpha(white, 0),
+	}.Add(gtx.Ops)
+	paint.PaintOp{}.Add(gtx.Ops)
+	paint.LinearGradientOp{
+		Stop1: f32.Pt(0, 0), Color1: f32color.MulAlpha(black, 0),
+		Stop2: f32.Pt(0, fsize.Y), Color2: black,
+	}.Add(gtx.Ops)
+	paint.PaintOp{}.Add(gtx.Ops)
+	area.Pop()
+	pos := image.Pt(int(s*fsize.X), int((1-v)*fsize.Y))
+	p.thumb(gtx, pos, gtx.Dp(p.SliderHeight)/2+gtx.Dp(2))
+	gtx.Constraints.Min = size
+	return p.Picker.LayoutArea(gtx)
+}
+
+// slider lays out the Float f over the track drawn by track.
+func (p ColorPickerStyle) slider(gtx layout.Context, f *widget.Float, track func(gtx layout.Context, r image.Rectangle)) layout.Dimensions {
+	size := image.Pt(gtx.Constraints.Max.X, gtx.Dp(p.SliderHeight))
+	r := image.Rectangle{Max: size}
+	rr := clip.UniformRRect(r, size.Y/2).Push(gtx.Ops)
+	track(gtx, r)
+	rr.Pop()
+	p.thumb(gtx, image.Pt(int(f.Value*float32(size.X)), size.Y/2), size.Y/2+gtx.Dp(2))
+	gtx.Constraints.Min = size
+	return f.Layout(gtx, layout.Horizontal, p.SliderHeight/2)
+}
+
+func (p ColorPickerStyle) hueTrack(gtx layout.Context, r image.Rectangle) {
+	const segments = 6
+	w := float32(r.Dx()) / segments
+	for i := 0; i < segments; i++ {
+		x0, x1 := float32(i)*w, float32(i+1)*w
+		seg := clip.Rect{Min: image.Pt(int(x0), 0), Max: image.Pt(int(x1+1), r.Max.Y)}.Push(gtx.Ops)
+		paint.LinearGradientOp{
+			Stop1: f32.Pt(x0, 0), Color1: hueColor(float32(i) * 60),
+			Stop2: f32.Pt(x1, 0), Color2: hueColor(float32(i+1) * 60),
+		}.Add(gtx.Ops)
+		paint.PaintOp{}.Add(gtx.Ops)
+		seg.Pop()
+	}
+}
+
+func (p ColorPickerStyle) alphaTrack(gtx layout.Context, r image.Rectangle) {
+	p.checker(gtx, r)
+	c := p.Picker.Color()
+	c.A = 0xff
+	paint.LinearGradientOp{
+		Stop1: f32.Pt(0, 0), Color1: f32color.MulAlpha(c, 0),
+		Stop2: f32.Pt(float32(r.Max.X), 0), Color2: c,
+	}.Add(gtx.Ops)
+	paint.PaintOp{}.Add(gtx.Ops)
+}
+
+// checker draws a checkerboard in r, to show the translucency of colors
+// drawn above it.
+func (p ColorPickerStyle) checker(gtx layout.Context, r image.Rectangle) {
+	paint.FillShape(gtx.Ops, p.ThumbColor, clip.Rect(r).Op())
+	sq := max(gtx.Dp(4), 1)
+	for y := r.Min.Y; y < r.Max.Y; y += sq {
+		for x := r.Min.X + ((y-r.Min.Y)/sq%2)*sq; x < r.Max.X; x += 2 * sq {
+			cell := image.Rect(x, y, x+sq, y+sq).Intersect(r)
+			paint.FillShape(gtx.Ops, p.CheckerColor, clip.Rect(cell).Op())
+		}
+	}
+}
+
+// thumb draws a ring with the radius radius centered at pos.
+func (p ColorPickerStyle) thumb(gtx layout.Context, pos image.Point, radius int) {
+	r := image.Rectangle{Min: pos.Sub(image.Pt(radius, radius)), Max: pos.Add(image.Pt(radius, radius))}
+	width := float32(gtx.Dp(2))
+	paint.FillShape(gtx.Ops, f32color.MulAlpha(color.NRGBA{A: 0xff}, 0x66), clip.Stroke{
+		Path:  clip.Ellipse(r.Inset(-1)).Path(gtx.Ops),
+		Width: 1,
+	}.Op())
+	paint.FillShape(gtx.Ops, p.ThumbColor, clip.Stroke{
+		Path:  clip.Ellipse(r).Path(gtx.Ops),
+		Width: width,
+	}.Op())
+}
+
+// swatch draws the color c above a checkerboard.
+func (p ColorPickerStyle) swatch(gtx layout.Context, c color.NRGBA, size image.Point) layout.Dimensions {
+	r := image.Rectangle{Max: size}
+	rr := gtx.Dp(p.CornerRadius)
+	area := clip.UniformRRect(r, rr).Push(gtx.Ops)
+	if c.A != 0xff {
+		p.checker(gtx, r)
+	}
+	paint.Fill(gtx.Ops, c)
+	area.Pop()
+	paint.FillShape(gtx.Ops, p.BorderColor, clip.Stroke{
+		Path:  clip.UniformRRect(r, rr).Path(gtx.Ops),
+		Width: float32(gtx.Dp(1)),
+	}.Op())
+	return layout.Dimensions{Size: size}
+}
+
+func (p ColorPickerStyle) editors(gtx layout.Context) layout.Dimensions {
+	field := func(e EditorStyle) layout.Widget {
+		return func(gtx layout.Context) layout.Dimensions {
+			return p.field(gtx, e)
+		}
+	}
+	space := layout.Rigid(layout.Spacer{Width: p.Spacing / 2}.Layout)
+	return layout.Flex{}.Layout(gtx,
+		layout.Flexed(2, field(p.Hex)),
+		space,
+		layout.Flexed(1, field(p.RGB[0])),
+		space,
+		layout.Flexed(1, field(p.RGB[1])),
+		space,
+		layout.Flexed(1, field(p.RGB[2])),
+	)
+}
+
+// field lays out the editor e inside an outline.
+func (p ColorPickerStyle) field(gtx layout.Context, e EditorStyle) layout.Dimensions {
+	macro := op.Record(gtx.Ops)
+	dims := layout.UniformInset(6).Layout(gtx, e.Layout)
+	call := macro.Stop()
+	border, width := p.BorderColor, gtx.Dp(1)
+	if e.Editor.Focused() {
+		border, width = e.SelectionColor, gtx.Dp(2)
+		border.A = 0xff
+	}
+	paint.FillShape(gtx.Ops, border, clip.Stroke{
+		Path:  clip.UniformRRect(image.Rectangle{Max: dims.Size}, gtx.Dp(p.CornerRadius)).Path(gtx.Ops),
+		Width: float32(width),
+	}.Op())
+	call.Add(gtx.Ops)
+	return dims
+}
+
+// hueColor returns the fully saturated, opaque color of the hue h in
+// degrees.
+func hueColor(h float32) color.NRGBA {
+	// The components ramp up and down in 60 degree steps.
+	c := func(n float32) uint8 {
+		k := n + h/60
+		for k >= 6 {
+			k -= 6
+		}
+		v := k
+		if 4-k < v {
+			v = 4 - k
+		}
+		if v > 1 {
+			v = 1
+		}
+		if v < 0 {
+			v = 0
+		}
+		return uint8((1-v)*255 + .5)
+	}
+	return color.NRGBA{R: c(5), G: c(3), B: c(1), A: 0xff}
+}
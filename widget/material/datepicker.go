@@ -0,0 +1,297 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package material
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/internal/f32color"
+	"gioui.org/io/semantic"
+	"gioui.org/io/system"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget"
+)
+
+// CalendarStyle configures the presentation of a calendar with a header
+// for changing the month. It can be laid out inline in a form, or as
+// the content of a Dialog to pick a date in a popup.
+type CalendarStyle struct {
+	Calendar *widget.Calendar
+	// Color is the color of text and icons.
+	Color color.NRGBA
+	// SelectedColor is the background color of selected days, and
+	// SelectedTextColor their text color.
+	SelectedColor     color.NRGBA
+	SelectedTextColor color.NRGBA
+	// RangeColor is the background color of the days of a selected
+	// range.
+	RangeColor color.NRGBA
+	// TodayColor is the color of the outline of today.
+	TodayColor color.NRGBA
+	// HoverColor is the color of the focused day.
+	HoverColor color.NRGBA
+	Font       font.Font
+	TextSize   unit.Sp
+	// DaySize is the size of the day cells.
+	DaySize unit.Dp
+	// MonthNames are the names of the months in the header, starting
+	// with January.
+	MonthNames [12]string
+	// WeekdayNames are the column headers of the weekdays, starting
+	// with Sunday.
+	WeekdayNames [7]string
+	// PrevIcon and NextIcon are the icons of the buttons that show the
+	// previous and next months in left-to-right locales. They are
+	// swapped in right-to-left locales.
+	PrevIcon *widget.Icon
+	NextIcon *widget.Icon
+	shaper   *text.Shaper
+}
+
+// TimePickerStyle configures the presentation of a time of day input.
+// Like CalendarStyle, it can be laid out inline or in a Dialog.
+type TimePickerStyle struct {
+	Picker *widget.TimePicker
+	// Color is the color of text.
+	Color color.NRGBA
+	// Background is the background color of fields, and FocusColor the
+	// background color of the focused field.
+	Background color.NRGBA
+	FocusColor color.NRGBA
+	// FocusTextColor is the text color of the focused field.
+	FocusTextColor color.NRGBA
+	Font           font.Font
+	TextSize       unit.Sp
+	CornerRadius   unit.Dp
+	// Inset is the inset of the field text.
+	Inset layout.Inset
+	// PeriodNames are the labels of the AM and PM periods of 12-hour
+	// clocks.
+	PeriodNames [2]string
+	shaper      *text.Shaper
+}
+
+func Calendar(th *Theme, calendar *widget.Calendar) CalendarStyle {
+	c := CalendarStyle{
+		Calendar:          calendar,
+		Color:             th.Palette.Fg,
+		SelectedColor:     th.Palette.ContrastBg,
+		SelectedTextColor: th.Palette.ContrastFg,
+		RangeColor:        f32color.MulAlpha(th.Palette.ContrastBg, 0x33),
+		TodayColor:        th.Palette.ContrastBg,
+		HoverColor:        f32color.MulAlpha(th.Palette.Fg, 0x1f),
+		TextSize:          th.TextSize * 14.0 / 16.0,
+		DaySize:           40,
+		MonthNames: [12]string{
+			"January", "February", "March", "April", "May", "June",
+			"July", "August", "September", "October", "November", "December",
+		},
+		WeekdayNames: [7]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"},
+		PrevIcon:     th.Icon.CalendarPrev,
+		NextIcon:     th.Icon.CalendarNext,
+		shaper:       th.Shaper,
+	}
+	c.Font.Typeface = th.Face
+	return c
+}
+
+func TimePicker(th *Theme, picker *widget.TimePicker) TimePickerStyle {
+	t := TimePickerStyle{
+		Picker:         picker,
+		Color:          th.Palette.Fg,
+		Background:     f32color.MulAlpha(th.Palette.Fg, 0x14),
+		FocusColor:     f32color.MulAlpha(th.Palette.ContrastBg, 0x33),
+		FocusTextColor: th.Palette.ContrastBg,
+		TextSize:       th.TextSize * 2,
+		CornerRadius:   8,
+		Inset: layout.Inset{
+			Top: 4, Bottom: 4,
+			Left: 12, Right: 12,
+		},
+		PeriodNames: [2]string{"AM", "PM"},
+		shaper:      th.Shaper,
+	}
+	t.Font.Typeface = th.Face
+	return t
+}
+
+// Layout the header with the month and buttons for changing it above the
+// month grid.
+func (c CalendarStyle) Layout(gtx layout.Context) layout.Dimensions {
+	c.Calendar.Update(gtx)
+	gtx.Constraints.Min.X = 0
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			// The header is as wide as the grid.
+			gtx.Constraints.Min.X = min(7*gtx.Dp(c.DaySize), gtx.Constraints.Max.X)
+			return c.header(gtx)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return c.Calendar.Layout(gtx, c.weekday, c.day)
+		}),
+	)
+}
+
+func (c CalendarStyle) header(gtx layout.Context) layout.Dimensions {
+	month := c.Calendar.Month()
+	title := fmt.Sprintf("%s %d", c.MonthNames[month.Month()-1], month.Year())
+	prev := func(gtx layout.Context) layout.Dimensions {
+		return c.button(gtx, &c.Calendar.PrevMonth, c.PrevIcon, "Previous month")
+	}
+	next := func(gtx layout.Context) layout.Dimensions {
+		return c.button(gtx, &c.Calendar.NextMonth, c.NextIcon, "Next month")
+	}
+	label := layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+		return layout.Inset{Left: 12, Right: 12}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			return c.label(gtx, title, c.Color, text.Start)
+		})
+	})
+	flex := layout.Flex{Alignment: layout.Middle}
+	if gtx.Locale.Direction.Progression() == system.TowardOrigin {
+		// Mirror the header, with the icons pointing the other way.
+		prev = func(gtx layout.Context) layout.Dimensions {
+			return c.button(gtx, &c.Calendar.PrevMonth, c.NextIcon, "Previous month")
+		}
+		next = func(gtx layout.Context) layout.Dimensions {
+			return c.button(gtx, &c.Calendar.NextMonth, c.PrevIcon, "Next month")
+		}
+		return flex.Layout(gtx, layout.Rigid(next), layout.Rigid(prev), label)
+	}
+	return flex.Layout(gtx, label, layout.Rigid(prev), layout.Rigid(next))
+}
+
+func (c CalendarStyle) button(gtx layout.Context, button *widget.Clickable, icon *widget.Icon, desc string) layout.Dimensions {
+	return button.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		semantic.Button.Add(gtx.Ops)
+		semantic.DescriptionOp(desc).Add(gtx.Ops)
+		size := gtx.Dp(c.DaySize)
+		r := image.Rectangle{Max: image.Pt(size, size)}
+		if button.Hovered() || button.Focused() {
+			paint.FillShape(gtx.Ops, c.HoverColor, clip.Ellipse(r).Op(gtx.Ops))
+		}
+		if icon != nil {
+			inset := size / 5
+			trans := op.Offset(image.Pt(inset, inset)).Push(gtx.Ops)
+			gtx.Constraints = layout.Exact(image.Pt(size-2*inset, size-2*inset))
+			fg := c.Color
+			if gtx.Queue == nil {
+				fg = f32color.Disabled(fg)
+			}
+			icon.Layout(gtx, fg)
+			trans.Pop()
+		}
+		return layout.Dimensions{Size: r.Max}
+	})
+}
+
+func (c CalendarStyle) weekday(gtx layout.Context, d time.Weekday) layout.Dimensions {
+	size := gtx.Dp(c.DaySize)
+	gtx.Constraints = layout.Exact(image.Pt(size, size*3/4))
+	return layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return c.label(gtx, c.WeekdayNames[d], f32color.MulAlpha(c.Color, 0x99), text.Middle)
+	})
+}
+
+func (c CalendarStyle) day(gtx layout.Context, date time.Time, s widget.DayState) layout.Dimensions {
+	semantic.LabelOp(fmt.Sprintf("%s %d, %d", c.MonthNames[date.Month()-1], date.Day(), date.Year())).Add(gtx.Ops)
+	semantic.SelectedOp(s.Selected).Add(gtx.Ops)
+	size := gtx.Dp(c.DaySize)
+	cell := image.Rectangle{Max: image.Pt(size, size)}
+	circle := cell.Inset(gtx.Dp(2))
+	fg := c.Color
+	if s.InRange {
+		paint.FillShape(gtx.Ops, c.RangeColor, clip.Rect{Min: image.Pt(0, circle.Min.Y), Max: image.Pt(size, circle.Max.Y)}.Op())
+	}
+	switch {
+	case s.Selected:
+		paint.FillShape(gtx.Ops, c.SelectedColor, clip.Ellipse(circle).Op(gtx.Ops))
+		fg = c.SelectedTextColor
+	case s.Today:
+		paint.FillShape(gtx.Ops, c.TodayColor, clip.Stroke{
+			Path:  clip.Ellipse(circle).Path(gtx.Ops),
+			Width: float32(gtx.Dp(1)),
+		}.Op())
+	}
+	if s.Focused {
+		paint.FillShape(gtx.Ops, c.HoverColor, clip.Ellipse(circle).Op(gtx.Ops))
+	}
+	if s.Outside {
+		fg = f32color.MulAlpha(fg, 0x66)
+	}
+	if s.Disabled || gtx.Queue == nil {
+		fg = f32color.Disabled(fg)
+	}
+	gtx.Constraints = layout.Exact(cell.Max)
+	return layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return c.label(gtx, fmt.Sprint(date.Day()), fg, text.Middle)
+	})
+}
+
+func (c CalendarStyle) label(gtx layout.Context, txt string, fg color.NRGBA, align text.Alignment) layout.Dimensions {
+	colMacro := op.Record(gtx.Ops)
+	paint.ColorOp{Color: fg}.Add(gtx.Ops)
+	l := widget.Label{MaxLines: 1, Alignment: align}
+	return l.Layout(gtx, c.shaper, c.Font, c.TextSize, txt, colMacro.Stop())
+}
+
+// Layout the hour and minute fields, and the AM/PM field for 12-hour
+// clocks.
+func (t TimePickerStyle) Layout(gtx layout.Context) layout.Dimensions {
+	sep := func(gtx layout.Context) layout.Dimensions {
+		return layout.Inset{Left: 4, Right: 4}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			return t.label(gtx, ":", t.Color)
+		})
+	}
+	return t.Picker.Layout(gtx, t.field, sep)
+}
+
+func (t TimePickerStyle) field(gtx layout.Context, s widget.TimeFieldState) layout.Dimensions {
+	txt := s.Text
+	if s.Field == widget.TimePeriod {
+		txt = t.PeriodNames[0]
+		if t.Picker.Hour >= 12 {
+			txt = t.PeriodNames[1]
+		}
+		gtx.Constraints.Min.X = 0
+		return layout.Inset{Left: 8}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			return t.box(gtx, txt, s, t.TextSize/2)
+		})
+	}
+	return t.box(gtx, txt, s, t.TextSize)
+}
+
+func (t TimePickerStyle) box(gtx layout.Context, txt string, s widget.TimeFieldState, size unit.Sp) layout.Dimensions {
+	semantic.LabelOp(txt).Add(gtx.Ops)
+	bg, fg := t.Background, t.Color
+	if s.Focused {
+		bg, fg = t.FocusColor, t.FocusTextColor
+	}
+	if gtx.Queue == nil {
+		bg, fg = f32color.Disabled(bg), f32color.Disabled(fg)
+	}
+	t.TextSize = size
+	macro := op.Record(gtx.Ops)
+	dims := t.Inset.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return t.label(gtx, txt, fg)
+	})
+	call := macro.Stop()
+	paint.FillShape(gtx.Ops, bg, clip.UniformRRect(image.Rectangle{Max: dims.Size}, gtx.Dp(t.CornerRadius)).Op(gtx.Ops))
+	call.Add(gtx.Ops)
+	return dims
+}
+
+func (t TimePickerStyle) label(gtx layout.Context, txt string, fg color.NRGBA) layout.Dimensions {
+	colMacro := op.Record(gtx.Ops)
+	paint.ColorOp{Color: fg}.Add(gtx.Ops)
+	l := widget.Label{MaxLines: 1}
+	return l.Layout(gtx, t.shaper, t.Font, t.TextSize, txt, colMacro.Stop())
+}
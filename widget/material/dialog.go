@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package material
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/internal/f32color"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+)
+
+// DialogStyle configures the presentation of a modal dialog with a
+// title, content and action buttons.
+type DialogStyle struct {
+	Modal *widget.Modal
+	Title LabelStyle
+	// ScrimColor is the color drawn over the content beneath the dialog.
+	ScrimColor   color.NRGBA
+	Background   color.NRGBA
+	CornerRadius unit.Dp
+	Inset        layout.Inset
+	// MinWidth and MaxWidth bound the width of the dialog.
+	MinWidth, MaxWidth unit.Dp
+	// Margin is the minimum space between the dialog and the window
+	// edges.
+	Margin unit.Dp
+}
+
+func Dialog(th *Theme, modal *widget.Modal, title string) DialogStyle {
+	return DialogStyle{
+		Modal:        modal,
+		Title:        H6(th, title),
+		ScrimColor:   f32color.MulAlpha(rgb(0x000000), 0x52),
+		Background:   th.Palette.Bg,
+		CornerRadius: 28,
+		Inset:        layout.UniformInset(24),
+		MinWidth:     280,
+		MaxWidth:     560,
+		Margin:       24,
+	}
+}
+
+// Layout the dialog when its modal is open. The action widgets, such as
+// buttons, are laid out in a row at the bottom right of the dialog.
+// Like the Modal, Layout the dialog with the constraints of the window
+// after the widgets it covers.
+func (d DialogStyle) Layout(gtx layout.Context, content layout.Widget, actions ...layout.Widget) layout.Dimensions {
+	scrim := func(gtx layout.Context) layout.Dimensions {
+		paint.Fill(gtx.Ops, d.ScrimColor)
+		return layout.Dimensions{Size: gtx.Constraints.Min}
+	}
+	return d.Modal.Layout(gtx, scrim, func(gtx layout.Context) layout.Dimensions {
+		margin := gtx.Dp(d.Margin)
+		gtx.Constraints.Max = gtx.Constraints.Max.Sub(image.Pt(2*margin, 2*margin))
+		gtx.Constraints.Max.X = max(min(gtx.Constraints.Max.X, gtx.Dp(d.MaxWidth)), 0)
+		gtx.Constraints.Max.Y = max(gtx.Constraints.Max.Y, 0)
+		gtx.Constraints.Min = image.Pt(min(gtx.Dp(d.MinWidth), gtx.Constraints.Max.X), 0)
+		return layout.Background{}.Layout(gtx,
+			func(gtx layout.Context) layout.Dimensions {
+				rr := gtx.Dp(d.CornerRadius)
+				defer clip.UniformRRect(image.Rectangle{Max: gtx.Constraints.Min}, rr).Push(gtx.Ops).Pop()
+				paint.Fill(gtx.Ops, d.Background)
+				return layout.Dimensions{Size: gtx.Constraints.Min}
+			},
+			func(gtx layout.Context) layout.Dimensions {
+				return d.Inset.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return d.layoutContent(gtx, content, actions)
+				})
+			},
+		)
+	})
+}
+
+func (d DialogStyle) layoutContent(gtx layout.Context, content layout.Widget, actions []layout.Widget) layout.Dimensions {
+	body := layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(d.Title.Layout),
+		layout.Rigid(layout.Spacer{Height: 16}.Layout),
+		layout.Rigid(content),
+	)
+	if len(actions) == 0 {
+		return body
+	}
+	// Align the actions with the right edge of the body.
+	gap := gtx.Dp(24)
+	agtx := gtx
+	agtx.Constraints.Min = image.Pt(body.Size.X, 0)
+	agtx.Constraints.Max.Y = max(agtx.Constraints.Max.Y-body.Size.Y-gap, 0)
+	defer op.Offset(image.Pt(0, body.Size.Y+gap)).Push(gtx.Ops).Pop()
+	adims := layout.E.Layout(agtx, func(gtx layout.Context) layout.Dimensions {
+		return d.actions(gtx, actions)
+	})
+	size := image.Pt(max(body.Size.X, adims.Size.X), body.Size.Y+gap+adims.Size.Y)
+	return layout.Dimensions{Size: gtx.Constraints.Constrain(size)}
+}
+
+func (d DialogStyle) actions(gtx layout.Context, actions []layout.Widget) layout.Dimensions {
+	children := make([]layout.FlexChild, 0, 2*len(actions))
+	for i, a := range actions {
+		if i > 0 {
+			children = append(children, layout.Rigid(layout.Spacer{Width: 8}.Layout))
+		}
+		children = append(children, layout.Rigid(a))
+	}
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx, children...)
+}
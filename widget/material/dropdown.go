@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package material
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/font"
+	"gioui.org/internal/f32color"
+	"gioui.org/io/semantic"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget"
+)
+
+// DropdownStyle configures the presentation of a dropdown.
+type DropdownStyle struct {
+	optionList
+	Dropdown *widget.Dropdown
+	// Hint is shown in the field when no option is selected.
+	Hint      string
+	HintColor color.NRGBA
+	// ArrowIcon is drawn at the end of the field.
+	ArrowIcon *widget.Icon
+}
+
+// ComboStyle configures the presentation of a combo box.
+type ComboStyle struct {
+	optionList
+	Combo  *widget.Combo
+	Editor EditorStyle
+}
+
+// optionList configures the presentation of the field and option list
+// of a dropdown or combo box.
+type optionList struct {
+	// Color is the color of text.
+	Color      color.NRGBA
+	Background color.NRGBA
+	// FocusColor is the color of the highlighted option, and
+	// SelectedColor the color of the text of the selected option.
+	FocusColor    color.NRGBA
+	SelectedColor color.NRGBA
+	// BorderColor is the color of the outlines of the field and list,
+	// and FocusBorderColor the color of the outline of the focused
+	// field.
+	BorderColor      color.NRGBA
+	FocusBorderColor color.NRGBA
+	Font             font.Font
+	TextSize         unit.Sp
+	CornerRadius     unit.Dp
+	// Inset is the inset of the field content.
+	Inset layout.Inset
+	// ItemHeight is the minimum height of options.
+	ItemHeight unit.Dp
+	// MaxHeight is the maximum height of the option list, if the
+	// MaxHeight of the widget is zero.
+	MaxHeight unit.Dp
+	shaper    *text.Shaper
+}
+
+func Dropdown(th *Theme, dropdown *widget.Dropdown, hint string) DropdownStyle {
+	return DropdownStyle{
+		optionList: newOptionList(th),
+		Dropdown:   dropdown,
+		Hint:       hint,
+		HintColor:  f32color.MulAlpha(th.Palette.Fg, 0xbb),
+		ArrowIcon:  th.Icon.DropdownArrow,
+	}
+}
+
+func Combo(th *Theme, combo *widget.Combo, hint string) ComboStyle {
+	return ComboStyle{
+		optionList: newOptionList(th),
+		Combo:      combo,
+		Editor:     Editor(th, &combo.Editor, hint),
+	}
+}
+
+func newOptionList(th *Theme) optionList {
+	l := optionList{
+		Color:            th.Palette.Fg,
+		Background:       th.Palette.Bg,
+		FocusColor:       f32color.MulAlpha(th.Palette.Fg, 0x1f),
+		SelectedColor:    th.Palette.ContrastBg,
+		BorderColor:      f32color.MulAlpha(th.Palette.Fg, 0x66),
+		FocusBorderColor: th.Palette.ContrastBg,
+		TextSize:         th.TextSize,
+		CornerRadius:     4,
+		Inset: layout.Inset{
+			Top: 8, Bottom: 8,
+			Left: 12, Right: 8,
+		},
+		ItemHeight: 36,
+		MaxHeight:  36 * 8,
+		shaper:     th.Shaper,
+	}
+	l.Font.Typeface = th.Face
+	return l
+}
+
+func (d DropdownStyle) Layout(gtx layout.Context) layout.Dimensions {
+	if d.Dropdown.MaxHeight == 0 {
+		d.Dropdown.MaxHeight = d.MaxHeight
+		defer func() { d.Dropdown.MaxHeight = 0 }()
+	}
+	d.Dropdown.Update(gtx)
+	field := func(gtx layout.Context) layout.Dimensions {
+		semantic.Button.Add(gtx.Ops)
+		txt, fg := d.Hint, d.HintColor
+		if s := d.Dropdown.Selected; s >= 0 && s < len(d.Dropdown.Options) {
+			txt, fg = d.Dropdown.Options[s], d.Color
+		}
+		return d.field(gtx, d.Dropdown.Focused() || d.Dropdown.Opened(), func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, d.label(txt, fg)),
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					size := gtx.Dp(24)
+					gtx.Constraints = layout.Exact(image.Pt(size, size))
+					if d.ArrowIcon != nil {
+						d.ArrowIcon.Layout(gtx, d.Color)
+					}
+					return layout.Dimensions{Size: gtx.Constraints.Min}
+				}),
+			)
+		})
+	}
+	return d.Dropdown.Layout(gtx, field, d.background, func(gtx layout.Context, i int, s widget.OptionState) layout.Dimensions {
+		return d.option(gtx, d.Dropdown.Options[i], s)
+	})
+}
+
+func (c ComboStyle) Layout(gtx layout.Context) layout.Dimensions {
+	c.Combo.Editor.SingleLine = true
+	if c.Combo.MaxHeight == 0 {
+		c.Combo.MaxHeight = c.MaxHeight
+		defer func() { c.Combo.MaxHeight = 0 }()
+	}
+	c.Combo.Update(gtx)
+	field := func(gtx layout.Context) layout.Dimensions {
+		return c.field(gtx, c.Combo.Editor.Focused(), c.Editor.Layout)
+	}
+	return c.Combo.Layout(gtx, field, c.background, func(gtx layout.Context, i int, s widget.OptionState) layout.Dimensions {
+		return c.option(gtx, c.Combo.Options[i], s)
+	})
+}
+
+// field lays out w inside an outline.
+func (l optionList) field(gtx layout.Context, focused bool, w layout.Widget) layout.Dimensions {
+	macro := op.Record(gtx.Ops)
+	dims := l.Inset.Layout(gtx, w)
+	call := macro.Stop()
+	border, width := l.BorderColor, gtx.Dp(1)
+	if focused {
+		border, width = l.FocusBorderColor, gtx.Dp(2)
+	}
+	if gtx.Queue == nil {
+		border = f32color.Disabled(border)
+	}
+	r := image.Rectangle{Max: dims.Size}
+	paint.FillShape(gtx.Ops, border, clip.Stroke{
+		Path:  clip.UniformRRect(r, gtx.Dp(l.CornerRadius)).Path(gtx.Ops),
+		Width: float32(width),
+	}.Op())
+	call.Add(gtx.Ops)
+	return dims
+}
+
+func (l optionList) background(gtx layout.Context) layout.Dimensions {
+	size := gtx.Constraints.Min
+	r := image.Rectangle{Max: size}
+	rr := gtx.Dp(l.CornerRadius)
+	paint.FillShape(gtx.Ops, l.BorderColor, clip.Stroke{
+		Path:  clip.UniformRRect(r, rr).Path(gtx.Ops),
+		Width: float32(gtx.Dp(1)),
+	}.Op())
+	defer clip.UniformRRect(r, rr).Push(gtx.Ops).Pop()
+	paint.Fill(gtx.Ops, l.Background)
+	return layout.Dimensions{Size: size}
+}
+
+func (l optionList) option(gtx layout.Context, txt string, s widget.OptionState) layout.Dimensions {
+	fg := l.Color
+	if s.Selected {
+		fg = l.SelectedColor
+	}
+	gtx.Constraints.Min.Y = gtx.Dp(l.ItemHeight)
+	macro := op.Record(gtx.Ops)
+	dims := layout.Inset{Left: 12, Right: 12}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.W.Layout(gtx, l.label(txt, fg))
+	})
+	call := macro.Stop()
+	if s.Focused {
+		paint.FillShape(gtx.Ops, l.FocusColor, clip.Rect{Max: dims.Size}.Op())
+	}
+	call.Add(gtx.Ops)
+	return dims
+}
+
+func (l optionList) label(txt string, c color.NRGBA) layout.Widget {
+	return func(gtx layout.Context) layout.Dimensions {
+		colMacro := op.Record(gtx.Ops)
+		paint.ColorOp{Color: c}.Add(gtx.Ops)
+		lbl := widget.Label{MaxLines: 1}
+		return lbl.Layout(gtx, l.shaper, l.Font, l.TextSize, txt, colMacro.Stop())
+	}
+}
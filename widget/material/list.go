@@ -7,6 +7,7 @@ import (
 	"image/color"
 	"math"
 
+	"gioui.org/internal/f32color"
 	"gioui.org/io/pointer"
 	"gioui.org/layout"
 	"gioui.org/op"
@@ -231,6 +232,30 @@ func (s ScrollbarStyle) layout(gtx layout.Context, axis layout.Axis, viewportSta
 	)
 }
 
+// drawOverscroll draws a glow at the edge of an area of the given size
+// scrolled beyond its ends by the overscroll distance.
+func drawOverscroll(gtx layout.Context, axis layout.Axis, size image.Point, overscroll int, col color.NRGBA) {
+	sz := axis.Convert(size)
+	// The glow is the part of an ellipse inside the area.
+	depth := overscroll
+	if depth < 0 {
+		depth = -depth
+	}
+	depth /= 2
+	if max := sz.Y / 4; depth > max {
+		depth = max
+	}
+	var glow image.Rectangle
+	if overscroll < 0 {
+		glow = image.Rect(-depth, -sz.Y/4, depth, sz.Y+sz.Y/4)
+	} else {
+		glow = image.Rect(sz.X-depth, -sz.Y/4, sz.X+depth, sz.Y+sz.Y/4)
+	}
+	glow = image.Rectangle{Min: axis.Convert(glow.Min), Max: axis.Convert(glow.Max)}
+	defer clip.Rect{Max: size}.Push(gtx.Ops).Pop()
+	paint.FillShape(gtx.Ops, col, clip.Ellipse(glow).Op(gtx.Ops))
+}
+
 // AnchorStrategy defines a means of attaching a scrollbar to content.
 type AnchorStrategy uint8
 
@@ -249,13 +274,17 @@ type ListStyle struct {
 	state *widget.List
 	ScrollbarStyle
 	AnchorStrategy
+	// OverscrollColor is the color of the glow at the edge of a list
+	// dragged beyond its ends. The zero value disables the glow.
+	OverscrollColor color.NRGBA
 }
 
 // List constructs a ListStyle using the provided theme and state.
 func List(th *Theme, state *widget.List) ListStyle {
 	return ListStyle{
-		state:          state,
-		ScrollbarStyle: Scrollbar(th, &state.Scrollbar),
+		state:           state,
+		ScrollbarStyle:  Scrollbar(th, &state.Scrollbar),
+		OverscrollColor: f32color.MulAlpha(th.Palette.ContrastBg, 0x60),
 	}
 }
 
@@ -285,6 +314,9 @@ func (l ListStyle) Layout(gtx layout.Context, length int, w layout.ListElement)
 
 	listDims := l.state.List.Layout(gtx, length, w)
 	gtx.Constraints = originalConstraints
+	if o := l.state.Overscroll(); o != 0 && l.OverscrollColor != (color.NRGBA{}) {
+		drawOverscroll(gtx, l.state.Axis, listDims.Size, o, l.OverscrollColor)
+	}
 
 	// Draw the scrollbar.
 	anchoring := layout.E
@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package material
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/font"
+	"gioui.org/internal/f32color"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget"
+)
+
+// MenuStyle configures the presentation of menus and their submenus.
+type MenuStyle struct {
+	Menu *widget.Menu
+	// Color is the color of item text.
+	Color color.NRGBA
+	// ShortcutColor is the color of accelerator text.
+	ShortcutColor color.NRGBA
+	Background    color.NRGBA
+	// FocusColor is the color of the focused item.
+	FocusColor color.NRGBA
+	// SeparatorColor is the color of separator items.
+	SeparatorColor color.NRGBA
+	// BorderColor is the color of the menu outline.
+	BorderColor  color.NRGBA
+	Font         font.Font
+	TextSize     unit.Sp
+	CornerRadius unit.Dp
+	// MinWidth is the minimum width of menus.
+	MinWidth unit.Dp
+	// ItemHeight is the minimum height of items.
+	ItemHeight unit.Dp
+	// CheckIcon marks checked items, and SubmenuIcon items with
+	// submenus.
+	CheckIcon   *widget.Icon
+	SubmenuIcon *widget.Icon
+	shaper      *text.Shaper
+}
+
+// MenuBarStyle configures the presentation of a menu bar.
+type MenuBarStyle struct {
+	MenuBar *widget.MenuBar
+	// Menu configures the menus of the bar. Its Menu field is
+	// ignored.
+	Menu  MenuStyle
+	Inset layout.Inset
+}
+
+// ContextAreaStyle configures the presentation of the menu of a
+// context area.
+type ContextAreaStyle struct {
+	Area *widget.ContextArea
+	// Menu configures the menu of the area. Its Menu field is
+	// ignored.
+	Menu MenuStyle
+}
+
+func Menu(th *Theme, menu *widget.Menu) MenuStyle {
+	m := MenuStyle{
+		Menu:           menu,
+		Color:          th.Palette.Fg,
+		ShortcutColor:  f32color.MulAlpha(th.Palette.Fg, 0x99),
+		Background:     th.Palette.Bg,
+		FocusColor:     f32color.MulAlpha(th.Palette.Fg, 0x1f),
+		SeparatorColor: f32color.MulAlpha(th.Palette.Fg, 0x1f),
+		BorderColor:    f32color.MulAlpha(th.Palette.Fg, 0x33),
+		TextSize:       th.TextSize * 14.0 / 16.0,
+		CornerRadius:   4,
+		MinWidth:       112,
+		ItemHeight:     32,
+		CheckIcon:      th.Icon.MenuCheck,
+		SubmenuIcon:    th.Icon.MenuSubmenu,
+		shaper:         th.Shaper,
+	}
+	m.Font.Typeface = th.Face
+	return m
+}
+
+func MenuBar(th *Theme, bar *widget.MenuBar) MenuBarStyle {
+	return MenuBarStyle{
+		MenuBar: bar,
+		Menu:    Menu(th, nil),
+		Inset: layout.Inset{
+			Top: 6, Bottom: 6,
+			Left: 12, Right: 12,
+		},
+	}
+}
+
+func ContextArea(th *Theme, area *widget.ContextArea) ContextAreaStyle {
+	return ContextAreaStyle{
+		Area: area,
+		Menu: Menu(th, nil),
+	}
+}
+
+func (m MenuStyle) Layout(gtx layout.Context) layout.Dimensions {
+	return m.Menu.Layout(gtx, m.background, m.item)
+}
+
+func (b MenuBarStyle) Layout(gtx layout.Context) layout.Dimensions {
+	return b.MenuBar.Layout(gtx, b.title, b.Menu.background, b.Menu.item)
+}
+
+// Layout the content widget and the menu.
+func (c ContextAreaStyle) Layout(gtx layout.Context, w layout.Widget) layout.Dimensions {
+	return c.Area.Layout(gtx, w, c.Menu.background, c.Menu.item)
+}
+
+func (m MenuStyle) background(gtx layout.Context) layout.Dimensions {
+	size := gtx.Constraints.Min
+	r := image.Rectangle{Max: size}
+	rr := gtx.Dp(m.CornerRadius)
+	paint.FillShape(gtx.Ops, m.BorderColor, clip.Stroke{
+		Path:  clip.UniformRRect(r, rr).Path(gtx.Ops),
+		Width: float32(gtx.Dp(1)),
+	}.Op())
+	defer clip.UniformRRect(r, rr).Push(gtx.Ops).Pop()
+	paint.Fill(gtx.Ops, m.Background)
+	return layout.Dimensions{Size: size}
+}
+
+func (m MenuStyle) item(gtx layout.Context, it *widget.MenuItem, s widget.MenuItemState) layout.Dimensions {
+	if it.Separator {
+		height := gtx.Dp(1)
+		margin := gtx.Dp(4)
+		size := image.Pt(gtx.Constraints.Min.X, height+2*margin)
+		r := image.Rect(0, margin, size.X, margin+height)
+		paint.FillShape(gtx.Ops, m.SeparatorColor, clip.Rect(r).Op())
+		return layout.Dimensions{Size: size}
+	}
+	if min := gtx.Dp(m.MinWidth); gtx.Constraints.Min.X < min {
+		gtx.Constraints.Min.X = min
+	}
+	gtx.Constraints.Min.X = gtx.Constraints.Constrain(gtx.Constraints.Min).X
+	gtx.Constraints.Min.Y = gtx.Dp(m.ItemHeight)
+	if s.Focused || s.Open {
+		macro := op.Record(gtx.Ops)
+		dims := m.itemContent(gtx, it)
+		call := macro.Stop()
+		paint.FillShape(gtx.Ops, m.FocusColor, clip.Rect{Max: dims.Size}.Op())
+		call.Add(gtx.Ops)
+		return dims
+	}
+	return m.itemContent(gtx, it)
+}
+
+func (m MenuStyle) itemContent(gtx layout.Context, it *widget.MenuItem) layout.Dimensions {
+	fg, sfg := m.Color, m.ShortcutColor
+	if it.Disabled {
+		fg = f32color.Disabled(fg)
+		sfg = f32color.Disabled(sfg)
+	}
+	icon := func(ic *widget.Icon, show bool) layout.Widget {
+		return func(gtx layout.Context) layout.Dimensions {
+			size := gtx.Dp(18)
+			gtx.Constraints = layout.Exact(image.Pt(size, size))
+			if show && ic != nil {
+				ic.Layout(gtx, fg)
+			}
+			return layout.Dimensions{Size: gtx.Constraints.Min}
+		}
+	}
+	label := func(txt string, c color.NRGBA) layout.Widget {
+		return func(gtx layout.Context) layout.Dimensions {
+			colMacro := op.Record(gtx.Ops)
+			paint.ColorOp{Color: c}.Add(gtx.Ops)
+			l := widget.Label{MaxLines: 1}
+			return l.Layout(gtx, m.shaper, m.Font, m.TextSize, txt, colMacro.Stop())
+		}
+	}
+	inset := layout.Inset{Left: 8, Right: 12}
+	return inset.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Alignment: layout.Middle, Spacing: layout.SpaceBetween}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(icon(m.CheckIcon, it.Checkable && it.Checked)),
+					layout.Rigid(layout.Spacer{Width: 8}.Layout),
+					layout.Rigid(label(it.Label, fg)),
+				)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if it.Shortcut == "" {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Left: 24}.Layout(gtx, label(it.Shortcut, sfg))
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if it.Submenu == nil {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Left: 8}.Layout(gtx, icon(m.SubmenuIcon, true))
+					}),
+				)
+			}),
+		)
+	})
+}
+
+func (b MenuBarStyle) title(gtx layout.Context, it *widget.MenuItem, s widget.MenuItemState) layout.Dimensions {
+	fg := b.Menu.Color
+	if it.Disabled {
+		fg = f32color.Disabled(fg)
+	}
+	macro := op.Record(gtx.Ops)
+	dims := b.Inset.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		colMacro := op.Record(gtx.Ops)
+		paint.ColorOp{Color: fg}.Add(gtx.Ops)
+		l := widget.Label{MaxLines: 1}
+		return l.Layout(gtx, b.Menu.shaper, b.Menu.Font, b.Menu.TextSize, it.Label, colMacro.Stop())
+	})
+	call := macro.Stop()
+	if s.Focused || s.Open {
+		rr := gtx.Dp(b.Menu.CornerRadius)
+		paint.FillShape(gtx.Ops, b.Menu.FocusColor, clip.UniformRRect(image.Rectangle{Max: dims.Size}, rr).Op(gtx.Ops))
+	}
+	call.Add(gtx.Ops)
+	return dims
+}
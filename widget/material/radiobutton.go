@@ -26,6 +26,7 @@ func RadioButton(th *Theme, group *widget.Enum, key, label string) RadioButtonSt
 			IconColor:          th.Palette.ContrastBg,
 			TextSize:           th.TextSize * 14.0 / 16.0,
 			Size:               26,
+			Duration:           th.motion(),
 			shaper:             th.Shaper,
 			checkedStateIcon:   th.Icon.RadioChecked,
 			uncheckedStateIcon: th.Icon.RadioUnchecked,
@@ -44,6 +45,8 @@ func (r RadioButtonStyle) Layout(gtx layout.Context) layout.Dimensions {
 	return r.Group.Layout(gtx, r.Key, func(gtx layout.Context) layout.Dimensions {
 		semantic.RadioButton.Add(gtx.Ops)
 		highlight := hovering && hovered == r.Key || focused && focus == r.Key
-		return r.layout(gtx, r.Group.Value == r.Key, highlight)
+		checked := transition(gtx, r.Group.ValueTransition(r.Key), r.Duration, r.Group.Value == r.Key)
+		hovered := transition(gtx, r.Group.HoverTransition(r.Key), r.Duration, highlight)
+		return r.layout(gtx, checked, hovered)
 	})
 }
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package material
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+)
+
+// PullToRefreshStyle configures the presentation of a pull-to-refresh
+// indicator above a vertical layout.List.
+type PullToRefreshStyle struct {
+	state *widget.PullToRefresh
+	list  *layout.List
+	// Size is the diameter of the indicator.
+	Size       unit.Dp
+	Background color.NRGBA
+	// Color is the color of the progress arc and loader.
+	Color color.NRGBA
+}
+
+// PullToRefresh constructs a PullToRefreshStyle using the provided theme
+// and state.
+func PullToRefresh(th *Theme, state *widget.PullToRefresh, list *layout.List) PullToRefreshStyle {
+	return PullToRefreshStyle{
+		state:      state,
+		list:       list,
+		Size:       36,
+		Background: th.Palette.Bg,
+		Color:      th.Palette.ContrastBg,
+	}
+}
+
+// Layout the widget w, which must lay out the list, and the refresh
+// indicator on top of it.
+//
+// Layout updates the state after w, and a refresh it triggers is
+// signalled only by setting the state's Refreshing field. To be notified
+// of triggered refreshes instead, call the state's Update method before
+// Layout.
+func (p PullToRefreshStyle) Layout(gtx layout.Context, w layout.Widget) layout.Dimensions {
+	dims := w(gtx)
+	p.state.Update(gtx, p.list)
+	pulled := p.state.Pulled(gtx)
+	if !p.state.Refreshing && pulled == 0 {
+		return dims
+	}
+	size := gtx.Dp(p.Size)
+	// The indicator follows the pull and rests below the top edge
+	// while refreshing.
+	y := size / 2
+	if !p.state.Refreshing {
+		y = int(pulled*float32(size*3/2)) - size
+		if max := size * 2; y > max {
+			y = max
+		}
+	}
+	defer clip.Rect{Max: dims.Size}.Push(gtx.Ops).Pop()
+	defer op.Offset(image.Pt((dims.Size.X-size)/2, y)).Push(gtx.Ops).Pop()
+	disc := image.Rectangle{Max: image.Pt(size, size)}
+	// Draw a shadow and the disc.
+	paint.FillShape(gtx.Ops, argb(0x33000000), clip.Ellipse(disc.Add(image.Pt(0, gtx.Dp(1)))).Op(gtx.Ops))
+	paint.FillShape(gtx.Ops, p.Background, clip.Ellipse(disc).Op(gtx.Ops))
+
+	inset := size / 5
+	gtx.Constraints = layout.Exact(image.Pt(size-2*inset, size-2*inset))
+	defer op.Offset(image.Pt(inset, inset)).Push(gtx.Ops).Pop()
+	if p.state.Refreshing {
+		LoaderStyle{Color: p.Color}.Layout(gtx)
+		return dims
+	}
+	// Draw an arc proportional to the pull.
+	if pulled > 1 {
+		pulled = 1
+	}
+	radius := gtx.Constraints.Min.X / 2
+	defer op.Offset(image.Pt(radius, radius)).Push(gtx.Ops).Pop()
+	start := float32(-math.Pi / 2)
+	end := start + pulled*math.Pi*1.8
+	paint.FillShape(gtx.Ops, p.Color, clipLoader(gtx.Ops, start, end, float32(radius)))
+	return dims
+}
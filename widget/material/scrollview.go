@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package material
+
+import (
+	"image"
+	"math"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+)
+
+// ScrollViewStyle configures the presentation of a widget.ScrollView with
+// scrollbars along both axes.
+type ScrollViewStyle struct {
+	state *widget.ScrollView
+	// Horizontal and Vertical style the scrollbars along the bottom and
+	// trailing edges.
+	Horizontal, Vertical ScrollbarStyle
+	AnchorStrategy
+}
+
+// ScrollView constructs a ScrollViewStyle using the provided theme and
+// state.
+func ScrollView(th *Theme, state *widget.ScrollView) ScrollViewStyle {
+	return ScrollViewStyle{
+		state:      state,
+		Horizontal: Scrollbar(th, &state.Horizontal),
+		Vertical:   Scrollbar(th, &state.Vertical),
+	}
+}
+
+// Layout the scroll view and its scrollbars.
+func (s ScrollViewStyle) Layout(gtx layout.Context, w layout.Widget) layout.Dimensions {
+	// The vertical scrollbar occupies width and the horizontal
+	// scrollbar occupies height.
+	bars := image.Pt(gtx.Dp(s.Vertical.Width()), gtx.Dp(s.Horizontal.Width()))
+	if s.AnchorStrategy == Occupy {
+		gtx.Constraints = gtx.Constraints.SubMax(bars)
+	}
+	dims := s.state.Layout(gtx, w)
+
+	barOrigin := dims.Size
+	if s.AnchorStrategy == Overlay {
+		barOrigin = barOrigin.Sub(bars)
+	}
+	content := s.state.ContentSize()
+	layoutBar := func(bar ScrollbarStyle, axis layout.Axis, offset image.Point, size image.Point) {
+		start, end := s.state.Viewport(axis)
+		defer op.Offset(offset).Push(gtx.Ops).Pop()
+		gtx := gtx
+		gtx.Constraints = layout.Exact(size)
+		bar.Layout(gtx, axis, start, end)
+	}
+	layoutBar(s.Vertical, layout.Vertical, image.Pt(barOrigin.X, 0), image.Pt(bars.X, dims.Size.Y))
+	layoutBar(s.Horizontal, layout.Horizontal, image.Pt(0, barOrigin.Y), image.Pt(dims.Size.X, bars.Y))
+
+	// Handle any changes to the view position as a result of user
+	// interaction with the scrollbars.
+	delta := image.Point{
+		X: int(math.Round(float64(s.state.Horizontal.ScrollDistance() * float32(content.X)))),
+		Y: int(math.Round(float64(s.state.Vertical.ScrollDistance() * float32(content.Y)))),
+	}
+	if delta != (image.Point{}) {
+		s.state.ScrollBy(delta)
+	}
+
+	if s.AnchorStrategy == Occupy {
+		dims.Size = dims.Size.Add(bars)
+	}
+	return dims
+}
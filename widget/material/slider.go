@@ -5,6 +5,7 @@ package material
 import (
 	"image"
 	"image/color"
+	"time"
 
 	"gioui.org/internal/f32color"
 	"gioui.org/layout"
@@ -21,6 +22,7 @@ func Slider(th *Theme, float *widget.Float) SliderStyle {
 		Color:      th.Palette.ContrastBg,
 		Float:      float,
 		FingerSize: th.FingerSize,
+		Duration:   th.motion(),
 	}
 }
 
@@ -30,6 +32,9 @@ type SliderStyle struct {
 	Float *widget.Float
 
 	FingerSize unit.Dp
+	// Duration of the thumb animations. The thumb follows changes to
+	// the value not made by dragging within about Duration.
+	Duration time.Duration
 }
 
 func (s SliderStyle) Layout(gtx layout.Context) layout.Dimensions {
@@ -52,7 +57,21 @@ func (s SliderStyle) Layout(gtx layout.Context) layout.Dimensions {
 	gtx.Constraints.Min = axis.Convert(image.Pt(sizeMain-2*tr, sizeCross))
 	dims := s.Float.Layout(gtx, axis, thumbRadius)
 	gtx.Constraints.Min = gtx.Constraints.Min.Add(axis.Convert(image.Pt(0, sizeCross)))
-	thumbPos := tr + int(s.Float.Value*float32(axis.Convert(dims.Size).X))
+	value := s.Float.Value
+	pos := s.Float.PositionSpring()
+	if s.Duration <= 0 || s.Float.Dragging() {
+		// The thumb follows the pointer of drags without lag.
+		pos.Set(value)
+	} else {
+		// A critically damped spring settles within about 6
+		// time constants.
+		w := 6 / s.Duration.Seconds()
+		pos.Stiffness = float32(w * w)
+		pos.DampingRatio = 1
+		value = pos.Update(gtx, value)
+	}
+	drag := transition(gtx, s.Float.DragTransition(), s.Duration, s.Float.Dragging())
+	thumbPos := tr + int(value*float32(axis.Convert(dims.Size).X))
 	trans.Pop()
 
 	color := s.Color
@@ -84,8 +103,17 @@ func (s SliderStyle) Layout(gtx layout.Context) layout.Dimensions {
 	)
 	paint.FillShape(gtx.Ops, f32color.MulAlpha(color, 96), clip.Rect(track).Op())
 
-	// Draw thumb.
+	// Draw thumb halo while dragging.
 	pt := image.Pt(thumbPos, sizeCross/2)
+	if drag > 0 {
+		halo := rect(
+			pt.X-2*tr, pt.Y-2*tr,
+			pt.X+2*tr, pt.Y+2*tr,
+		)
+		paint.FillShape(gtx.Ops, f32color.MulAlpha(color, uint8(70*drag)), clip.Ellipse(halo).Op(gtx.Ops))
+	}
+
+	// Draw thumb.
 	thumb := rect(
 		pt.X-tr, pt.Y-tr,
 		pt.X+tr, pt.Y+tr,
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package material
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/internal/f32color"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+)
+
+// SplitStyle configures the presentation of the divider of split panes.
+type SplitStyle struct {
+	Split *widget.Split
+	// Color is the color of the divider line, and ActiveColor the color
+	// of the divider while it is hovered, dragged or focused.
+	Color       color.NRGBA
+	ActiveColor color.NRGBA
+	// Bar is the thickness of the divider, and Width the thickness of
+	// its line.
+	Bar   unit.Dp
+	Width unit.Dp
+}
+
+func Split(th *Theme, split *widget.Split) SplitStyle {
+	return SplitStyle{
+		Split:       split,
+		Color:       f32color.MulAlpha(th.Palette.Fg, 0x1f),
+		ActiveColor: th.Palette.ContrastBg,
+		Bar:         8,
+		Width:       1,
+	}
+}
+
+// Layout the first and second panes.
+func (s SplitStyle) Layout(gtx layout.Context, first, second layout.Widget) layout.Dimensions {
+	s.Split.Bar = s.Bar
+	return s.Split.Layout(gtx, first, second, s.divider)
+}
+
+func (s SplitStyle) divider(gtx layout.Context, state widget.DividerState) layout.Dimensions {
+	size := gtx.Constraints.Min
+	main := state.Axis.Convert(size)
+	fg, width := s.Color, gtx.Dp(s.Width)
+	if state.Hovered || state.Dragging || state.Focused {
+		fg, width = s.ActiveColor, max(width, gtx.Dp(3))
+	}
+	if gtx.Queue == nil {
+		fg = f32color.Disabled(fg)
+	}
+	width = min(width, main.X)
+	x := (main.X - width) / 2
+	r := image.Rectangle{
+		Min: state.Axis.Convert(image.Pt(x, 0)),
+		Max: state.Axis.Convert(image.Pt(x+width, main.Y)),
+	}
+	paint.FillShape(gtx.Ops, fg, clip.Rect(r).Op())
+	return layout.Dimensions{Size: size}
+}
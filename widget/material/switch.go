@@ -5,6 +5,7 @@ package material
 import (
 	"image"
 	"image/color"
+	"time"
 
 	"gioui.org/internal/f32color"
 	"gioui.org/io/semantic"
@@ -23,6 +24,8 @@ type SwitchStyle struct {
 		Track    color.NRGBA
 	}
 	Switch *widget.Bool
+	// Duration of the toggle and hover animations.
+	Duration time.Duration
 }
 
 // Switch is for selecting a boolean value.
@@ -30,6 +33,7 @@ func Switch(th *Theme, swtch *widget.Bool, description string) SwitchStyle {
 	sw := SwitchStyle{
 		Switch:      swtch,
 		Description: description,
+		Duration:    th.motion(),
 	}
 	sw.Color.Enabled = th.Palette.ContrastBg
 	sw.Color.Disabled = th.Palette.Bg
@@ -51,10 +55,9 @@ func (s SwitchStyle) Layout(gtx layout.Context) layout.Dimensions {
 		X: trackWidth,
 		Y: trackHeight,
 	}}
-	col := s.Color.Disabled
-	if s.Switch.Value {
-		col = s.Color.Enabled
-	}
+	checked := transition(gtx, s.Switch.ValueTransition(), s.Duration, s.Switch.Value)
+	hovered := transition(gtx, s.Switch.HoverTransition(), s.Duration, s.Switch.Hovered() || s.Switch.Focused())
+	col := f32color.Lerp(s.Color.Disabled, s.Color.Enabled, checked)
 	if gtx.Queue == nil {
 		col = f32color.Disabled(col)
 	}
@@ -83,10 +86,8 @@ func (s SwitchStyle) Layout(gtx layout.Context) layout.Dimensions {
 	t.Pop()
 
 	// Compute thumb offset.
-	if s.Switch.Value {
-		xoff := trackWidth - thumbSize
-		defer op.Offset(image.Point{X: xoff}).Push(gtx.Ops).Pop()
-	}
+	xoff := int(float32(trackWidth-thumbSize)*checked + .5)
+	defer op.Offset(image.Point{X: xoff}).Push(gtx.Ops).Pop()
 
 	thumbRadius := thumbSize / 2
 
@@ -98,9 +99,9 @@ func (s SwitchStyle) Layout(gtx layout.Context) layout.Dimensions {
 		return clip.Ellipse(b).Op(gtx.Ops)
 	}
 	// Draw hover.
-	if s.Switch.Hovered() || s.Switch.Focused() {
+	if hovered > 0 {
 		r := thumbRadius * 10 / 17
-		background := f32color.MulAlpha(s.Color.Enabled, 70)
+		background := f32color.MulAlpha(s.Color.Enabled, uint8(70*hovered))
 		paint.FillShape(gtx.Ops, background, circle(thumbRadius, thumbRadius, r))
 	}
 
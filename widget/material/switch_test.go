@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package material_test
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+)
+
+func TestSwitchAnimation(t *testing.T) {
+	for _, reduced := range []bool{false, true} {
+		th := material.NewTheme()
+		th.Motion.Reduced = reduced
+		gtx := layout.Context{
+			Ops:         new(op.Ops),
+			Constraints: layout.Exact(image.Pt(100, 100)),
+			Now:         time.Unix(0, 0),
+		}
+		var sw widget.Bool
+		material.Switch(th, &sw, "").Layout(gtx)
+		sw.Value = true
+		gtx.Now = gtx.Now.Add(th.Motion.Duration / 2)
+		material.Switch(th, &sw, "").Layout(gtx)
+		p := sw.ValueTransition().Progress(gtx.Now)
+		switch {
+		case reduced && p != 1:
+			t.Errorf("toggle progress %v with reduced motion, want 1", p)
+		case !reduced && p != 0:
+			t.Errorf("toggle progress %v at the start of the animation, want 0", p)
+		}
+		gtx.Now = gtx.Now.Add(th.Motion.Duration / 2)
+		if p := sw.ValueTransition().Progress(gtx.Now); !reduced && (p <= 0 || p >= 1) {
+			t.Errorf("toggle progress %v halfway through the animation", p)
+		}
+	}
+}
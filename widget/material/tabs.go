@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package material
+
+import (
+	"image"
+	"image/color"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/internal/f32color"
+	"gioui.org/io/semantic"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget"
+)
+
+// TabsStyle configures the presentation of a tab bar with text tabs.
+type TabsStyle struct {
+	Tabs *widget.Tabs
+	// Color is the color of the text of unselected tabs, and
+	// SelectedColor the color of the selected tab.
+	Color         color.NRGBA
+	SelectedColor color.NRGBA
+	// IndicatorColor is the color of the line below the selected tab.
+	IndicatorColor color.NRGBA
+	// HoverColor is the color of hovered and focused tabs.
+	HoverColor color.NRGBA
+	// DividerColor is the color of the line below the tab bar.
+	DividerColor color.NRGBA
+	Font         font.Font
+	TextSize     unit.Sp
+	Inset        layout.Inset
+	// IndicatorHeight is the thickness of the selection indicator.
+	IndicatorHeight unit.Dp
+	// CloseIcon is the icon of the close buttons of Closable tabs.
+	CloseIcon *widget.Icon
+	// Duration is the duration of the indicator animation.
+	Duration time.Duration
+	shaper   *text.Shaper
+}
+
+func Tabs(th *Theme, tabs *widget.Tabs) TabsStyle {
+	t := TabsStyle{
+		Tabs:           tabs,
+		Color:          f32color.MulAlpha(th.Palette.Fg, 0x99),
+		SelectedColor:  th.Palette.ContrastBg,
+		IndicatorColor: th.Palette.ContrastBg,
+		HoverColor:     f32color.MulAlpha(th.Palette.Fg, 0x14),
+		DividerColor:   f32color.MulAlpha(th.Palette.Fg, 0x1f),
+		TextSize:       th.TextSize * 14.0 / 16.0,
+		Inset: layout.Inset{
+			Top: 12, Bottom: 12,
+			Left: 16, Right: 16,
+		},
+		IndicatorHeight: 2,
+		CloseIcon:       th.Icon.TabClose,
+		Duration:        th.motion(),
+		shaper:          th.Shaper,
+	}
+	t.Font.Typeface = th.Face
+	t.Font.Weight = font.Medium
+	return t
+}
+
+// Layout a tab for each title, and the selection indicator.
+func (t TabsStyle) Layout(gtx layout.Context, titles ...string) layout.Dimensions {
+	t.Tabs.Duration = t.Duration
+	gtx.Constraints.Min.Y = 0
+	dims := t.Tabs.Layout(gtx, len(titles), func(gtx layout.Context, i int, s widget.TabState) layout.Dimensions {
+		return t.tab(gtx, titles[i], s)
+	})
+	size := dims.Size
+	defer clip.Rect{Max: size}.Push(gtx.Ops).Pop()
+	divider := gtx.Dp(1)
+	paint.FillShape(gtx.Ops, t.DividerColor, clip.Rect{Min: image.Pt(0, size.Y-divider), Max: size}.Op())
+	if r, ok := t.Tabs.Indicator(gtx); ok {
+		r.Min.Y = r.Max.Y - gtx.Dp(t.IndicatorHeight)
+		paint.FillShape(gtx.Ops, t.IndicatorColor, clip.Rect(r).Op())
+	}
+	return dims
+}
+
+func (t TabsStyle) tab(gtx layout.Context, title string, s widget.TabState) layout.Dimensions {
+	semantic.LabelOp(title).Add(gtx.Ops)
+	fg := t.Color
+	if s.Selected {
+		fg = t.SelectedColor
+	}
+	if gtx.Queue == nil {
+		fg = f32color.Disabled(fg)
+	}
+	macro := op.Record(gtx.Ops)
+	dims := t.Inset.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		label := func(gtx layout.Context) layout.Dimensions {
+			colMacro := op.Record(gtx.Ops)
+			paint.ColorOp{Color: fg}.Add(gtx.Ops)
+			l := widget.Label{MaxLines: 1}
+			return l.Layout(gtx, t.shaper, t.Font, t.TextSize, title, colMacro.Stop())
+		}
+		if s.Close == nil {
+			return label(gtx)
+		}
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(label),
+			layout.Rigid(layout.Spacer{Width: 8}.Layout),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return t.closeButton(gtx, s.Close, fg)
+			}),
+		)
+	})
+	call := macro.Stop()
+	if s.Hovered || s.Focused {
+		paint.FillShape(gtx.Ops, t.HoverColor, clip.Rect{Max: dims.Size}.Op())
+	}
+	call.Add(gtx.Ops)
+	return dims
+}
+
+func (t TabsStyle) closeButton(gtx layout.Context, button *widget.Clickable, fg color.NRGBA) layout.Dimensions {
+	return button.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		semantic.Button.Add(gtx.Ops)
+		semantic.DescriptionOp("Close").Add(gtx.Ops)
+		size := gtx.Dp(18)
+		r := image.Rectangle{Max: image.Pt(size, size)}
+		if button.Hovered() || button.Focused() {
+			paint.FillShape(gtx.Ops, t.HoverColor, clip.Ellipse(r).Op(gtx.Ops))
+		}
+		if t.CloseIcon != nil {
+			inset := gtx.Dp(2)
+			trans := op.Offset(image.Pt(inset, inset)).Push(gtx.Ops)
+			gtx.Constraints.Min = image.Pt(size-2*inset, 0)
+			t.CloseIcon.Layout(gtx, fg)
+			trans.Pop()
+		}
+		return layout.Dimensions{Size: r.Max}
+	})
+}
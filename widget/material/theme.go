@@ -4,10 +4,13 @@ package material
 
 import (
 	"image/color"
+	"time"
 
 	"golang.org/x/exp/shiny/materialdesign/icons"
 
+	"gioui.org/animation"
 	"gioui.org/font"
+	"gioui.org/layout"
 	"gioui.org/text"
 	"gioui.org/unit"
 	"gioui.org/widget"
@@ -41,12 +44,30 @@ type Theme struct {
 		CheckBoxUnchecked *widget.Icon
 		RadioChecked      *widget.Icon
 		RadioUnchecked    *widget.Icon
+		MenuCheck         *widget.Icon
+		MenuSubmenu       *widget.Icon
+		TabClose          *widget.Icon
+		DropdownArrow     *widget.Icon
+		TreeExpand        *widget.Icon
+		TreeCollapse      *widget.Icon
+		CalendarPrev      *widget.Icon
+		CalendarNext      *widget.Icon
 	}
 	// Face selects the default typeface for text.
 	Face font.Typeface
 
 	// FingerSize is the minimum touch target size.
 	FingerSize unit.Dp
+
+	// Motion configures the animation of state changes, such as
+	// hovering, checking and toggling widgets.
+	Motion struct {
+		// Duration of state change animations.
+		Duration time.Duration
+		// Reduced disables animations, for users that prefer
+		// reduced motion.
+		Reduced bool
+	}
 }
 
 // NewTheme constructs a theme (and underlying text shaper).
@@ -64,10 +85,20 @@ func NewTheme() *Theme {
 	t.Icon.CheckBoxUnchecked = mustIcon(widget.NewIcon(icons.ToggleCheckBoxOutlineBlank))
 	t.Icon.RadioChecked = mustIcon(widget.NewIcon(icons.ToggleRadioButtonChecked))
 	t.Icon.RadioUnchecked = mustIcon(widget.NewIcon(icons.ToggleRadioButtonUnchecked))
+	t.Icon.MenuCheck = mustIcon(widget.NewIcon(icons.NavigationCheck))
+	t.Icon.MenuSubmenu = mustIcon(widget.NewIcon(icons.NavigationChevronRight))
+	t.Icon.TabClose = mustIcon(widget.NewIcon(icons.NavigationClose))
+	t.Icon.DropdownArrow = mustIcon(widget.NewIcon(icons.NavigationArrowDropDown))
+	t.Icon.TreeExpand = mustIcon(widget.NewIcon(icons.NavigationChevronRight))
+	t.Icon.TreeCollapse = mustIcon(widget.NewIcon(icons.NavigationExpandMore))
+	t.Icon.CalendarPrev = mustIcon(widget.NewIcon(icons.NavigationChevronLeft))
+	t.Icon.CalendarNext = mustIcon(widget.NewIcon(icons.NavigationChevronRight))
 
 	// 38dp is on the lower end of possible finger size.
 	t.FingerSize = 38
 
+	t.Motion.Duration = 150 * time.Millisecond
+
 	return t
 }
 
@@ -76,6 +107,23 @@ func (t Theme) WithPalette(p Palette) Theme {
 	return t
 }
 
+// motion returns the duration of state change animations, or zero if
+// motion is reduced.
+func (t *Theme) motion() time.Duration {
+	if t.Motion.Reduced {
+		return 0
+	}
+	return t.Motion.Duration
+}
+
+// transition updates the transition t to the state on with duration d,
+// and returns its progress.
+func transition(gtx layout.Context, t *animation.Transition, d time.Duration, on bool) float32 {
+	t.Duration = d
+	t.Curve = animation.EaseInOut
+	return t.Update(gtx, on)
+}
+
 func mustIcon(ic *widget.Icon, err error) *widget.Icon {
 	if err != nil {
 		panic(err)
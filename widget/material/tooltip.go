@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package material
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/internal/f32color"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+)
+
+// TooltipStyle configures the presentation of a tooltip with a text tip,
+// such as the Description of an IconButton.
+type TooltipStyle struct {
+	Tooltip *widget.Tooltip
+	// Text is the style of the tip text.
+	Text         LabelStyle
+	Background   color.NRGBA
+	CornerRadius unit.Dp
+	Inset        layout.Inset
+	// MaxWidth is the width at which the tip text is wrapped.
+	MaxWidth unit.Dp
+}
+
+// Tooltip returns a style for a tooltip of txt, in the inverted colors
+// of the theme.
+func Tooltip(th *Theme, tooltip *widget.Tooltip, txt string) TooltipStyle {
+	text := Body2(th, txt)
+	text.Color = th.Palette.Bg
+	return TooltipStyle{
+		Tooltip:      tooltip,
+		Text:         text,
+		Background:   f32color.MulAlpha(th.Palette.Fg, 0xe6),
+		CornerRadius: 4,
+		Inset: layout.Inset{
+			Top: 4, Bottom: 4,
+			Left: 8, Right: 8,
+		},
+		MaxWidth: 240,
+	}
+}
+
+// Layout the anchor widget and the tooltip. Set the Bounds of the
+// Tooltip for the tip to stay inside the window.
+func (t TooltipStyle) Layout(gtx layout.Context, anchor layout.Widget) layout.Dimensions {
+	return t.Tooltip.Layout(gtx, anchor, func(gtx layout.Context) layout.Dimensions {
+		if max := gtx.Dp(t.MaxWidth); max > 0 && gtx.Constraints.Max.X > max {
+			gtx.Constraints.Max.X = max
+		}
+		return layout.Background{}.Layout(gtx,
+			func(gtx layout.Context) layout.Dimensions {
+				rr := gtx.Dp(t.CornerRadius)
+				defer clip.UniformRRect(image.Rectangle{Max: gtx.Constraints.Min}, rr).Push(gtx.Ops).Pop()
+				paint.Fill(gtx.Ops, t.Background)
+				return layout.Dimensions{Size: gtx.Constraints.Min}
+			},
+			func(gtx layout.Context) layout.Dimensions {
+				return t.Inset.Layout(gtx, t.Text.Layout)
+			},
+		)
+	})
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package material
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/font"
+	"gioui.org/internal/f32color"
+	"gioui.org/io/semantic"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget"
+)
+
+// TreeStyle configures the presentation of a tree view with text nodes.
+type TreeStyle struct {
+	Tree *widget.Tree
+	// Color is the color of text and expanders.
+	Color color.NRGBA
+	// SelectedColor is the background color of the selected node, and
+	// FocusColor the color of its outline when the tree is focused.
+	SelectedColor color.NRGBA
+	FocusColor    color.NRGBA
+	// GuideColor is the color of the indentation guides.
+	GuideColor color.NRGBA
+	Font       font.Font
+	TextSize   unit.Sp
+	// Indent is the indentation of each depth level.
+	Indent unit.Dp
+	// RowHeight is the minimum height of nodes.
+	RowHeight unit.Dp
+	// ExpandIcon marks collapsed nodes, and CollapseIcon expanded
+	// nodes.
+	ExpandIcon   *widget.Icon
+	CollapseIcon *widget.Icon
+	shaper       *text.Shaper
+}
+
+func Tree(th *Theme, tree *widget.Tree) TreeStyle {
+	t := TreeStyle{
+		Tree:          tree,
+		Color:         th.Palette.Fg,
+		SelectedColor: f32color.MulAlpha(th.Palette.ContrastBg, 0x33),
+		FocusColor:    th.Palette.ContrastBg,
+		GuideColor:    f32color.MulAlpha(th.Palette.Fg, 0x33),
+		TextSize:      th.TextSize * 14.0 / 16.0,
+		Indent:        20,
+		RowHeight:     28,
+		ExpandIcon:    th.Icon.TreeExpand,
+		CollapseIcon:  th.Icon.TreeCollapse,
+		shaper:        th.Shaper,
+	}
+	t.Font.Typeface = th.Face
+	return t
+}
+
+// Layout the tree with the label of each node.
+func (t TreeStyle) Layout(gtx layout.Context, label func(n *widget.TreeNode) string) layout.Dimensions {
+	t.Tree.Indent = t.Indent
+	return t.Tree.Layout(gtx, func(gtx layout.Context, n *widget.TreeNode, s widget.TreeNodeState) layout.Dimensions {
+		return t.node(gtx, label(n), n, s)
+	})
+}
+
+func (t TreeStyle) node(gtx layout.Context, txt string, n *widget.TreeNode, s widget.TreeNodeState) layout.Dimensions {
+	semantic.LabelOp(txt).Add(gtx.Ops)
+	semantic.SelectedOp(s.Selected).Add(gtx.Ops)
+	indent := gtx.Dp(t.Indent)
+	fg := t.Color
+	if gtx.Queue == nil {
+		fg = f32color.Disabled(fg)
+	}
+	gtx.Constraints.Min.Y = gtx.Dp(t.RowHeight)
+	macro := op.Record(gtx.Ops)
+	dims := layout.Inset{Left: t.Indent * unit.Dp(s.Depth+1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		gtx.Constraints.Min.X = 0
+		return layout.W.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			colMacro := op.Record(gtx.Ops)
+			paint.ColorOp{Color: fg}.Add(gtx.Ops)
+			l := widget.Label{MaxLines: 1}
+			return l.Layout(gtx, t.shaper, t.Font, t.TextSize, txt, colMacro.Stop())
+		})
+	})
+	call := macro.Stop()
+	size := image.Pt(gtx.Constraints.Min.X, dims.Size.Y)
+	if s.Selected {
+		r := image.Rectangle{Max: size}
+		paint.FillShape(gtx.Ops, t.SelectedColor, clip.Rect(r).Op())
+		if s.Focused {
+			paint.FillShape(gtx.Ops, t.FocusColor, clip.Stroke{
+				Path:  clip.Rect(r).Path(),
+				Width: float32(gtx.Dp(1)),
+			}.Op())
+		}
+	}
+	// Draw a guide at the center of each indentation column of the
+	// ancestors.
+	width := gtx.Dp(1)
+	for d := 0; d < s.Depth; d++ {
+		x := d*indent + indent/2
+		paint.FillShape(gtx.Ops, t.GuideColor, clip.Rect{Min: image.Pt(x, 0), Max: image.Pt(x+width, size.Y)}.Op())
+	}
+	if s.Expandable {
+		ic := t.ExpandIcon
+		if n.Expanded {
+			ic = t.CollapseIcon
+		}
+		if ic != nil {
+			isize := indent * 4 / 5
+			off := image.Pt(s.Depth*indent+(indent-isize)/2, (size.Y-isize)/2)
+			trans := op.Offset(off).Push(gtx.Ops)
+			igtx := gtx
+			igtx.Constraints = layout.Exact(image.Pt(isize, isize))
+			ic.Layout(igtx, fg)
+			trans.Pop()
+		}
+	}
+	call.Add(gtx.Ops)
+	return layout.Dimensions{Size: size}
+}
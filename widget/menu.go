@@ -0,0 +1,587 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget
+
+import (
+	"image"
+	"time"
+
+	"gioui.org/gesture"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+)
+
+// Menu holds the state of a popup menu. A menu is shown by Open and
+// drawn by Layout above other content. It is closed when an item is
+// activated, when Escape is pressed, or when a pointer presses outside
+// of it.
+type Menu struct {
+	Items []MenuItem
+
+	open bool
+	// anchor is the rectangle the menu is placed next to.
+	anchor image.Rectangle
+	// focus is the index of the focused item, or -1.
+	focus int
+	// parent is the menu of a submenu, and bar the menu bar of a
+	// top level menu.
+	parent *Menu
+	bar    *MenuBar
+	items  []menuItem
+	// activated is the queue of activated items of the menu and its
+	// submenus.
+	activated    []*MenuItem
+	requestFocus bool
+	focused      bool
+	// scrim is the tag for presses outside the menu.
+	scrim bool
+}
+
+// MenuItem is an item of a Menu.
+type MenuItem struct {
+	// Label is the text of the item.
+	Label string
+	// Shortcut is the text of the keyboard accelerator of the item,
+	// such as "Ctrl+S". Menus only display accelerators; handle the
+	// key presses with key.InputOp.
+	Shortcut string
+	// Disabled items can't be focused or activated.
+	Disabled bool
+	// Checkable items toggle Checked when they are activated.
+	Checkable bool
+	Checked   bool
+	// Separator items separate groups of items. Their other fields
+	// are ignored.
+	Separator bool
+	// Submenu, if not nil, is the menu opened by the item.
+	Submenu *Menu
+}
+
+// MenuItemState is the state of a menu item for drawing.
+type MenuItemState struct {
+	// Focused reports whether the item is focused by keyboard or
+	// pointer.
+	Focused bool
+	// Pressed reports whether a pointer is pressing the item.
+	Pressed bool
+	// Open reports whether the submenu of the item is open.
+	Open bool
+}
+
+// MenuItemWidget lays out a menu item. The item is laid out twice per
+// frame: first with zero minimum constraints to measure its size, then
+// with the minimum width of the widest item of the menu.
+type MenuItemWidget func(gtx layout.Context, item *MenuItem, state MenuItemState) layout.Dimensions
+
+// MenuBar holds the state of a row of menus, such as the menu bar at the
+// top of a window. The Submenu of every item is the menu opened by
+// the item.
+type MenuBar struct {
+	Items []MenuItem
+
+	items []menuItem
+}
+
+// ContextArea opens a menu at the position of secondary button clicks
+// and touch long presses on its content.
+type ContextArea struct {
+	Menu Menu
+
+	click gesture.Click
+}
+
+type menuItem struct {
+	click   gesture.Click
+	hovered bool
+	// rect is the item bounds in the coordinates of the menu.
+	rect image.Rectangle
+}
+
+const contextLongPress = 500 * time.Millisecond
+
+var menuKeys = key.Set("↑|↓|←|→|⇱|⇲|⏎|⌤|Space|⎋")
+
+// Open the menu next to the anchor rectangle, in the coordinates of
+// Layout. The menu is placed below the anchor, or above it if there
+// is no room below.
+func (m *Menu) Open(anchor image.Rectangle) {
+	m.closeSubmenus()
+	m.open = true
+	m.anchor = anchor
+	m.focus = -1
+	m.requestFocus = true
+}
+
+// Close the menu and its submenus.
+func (m *Menu) Close() {
+	m.closeSubmenus()
+	m.open = false
+	m.focused = false
+}
+
+// Opened reports whether the menu is open.
+func (m *Menu) Opened() bool {
+	return m.open
+}
+
+// Focused reports whether the menu has the keyboard focus. Submenus
+// opened by the keyboard take the focus.
+func (m *Menu) Focused() bool {
+	return m.focused
+}
+
+// Activated returns the next activated item of the menu or its
+// submenus. Activating a Checkable item toggles its Checked field
+// before it is returned.
+func (m *Menu) Activated() (*MenuItem, bool) {
+	if len(m.activated) == 0 {
+		return nil, false
+	}
+	it := m.activated[0]
+	m.activated = m.activated[1:]
+	return it, true
+}
+
+// Layout the open menu and its open submenus, with the background
+// widget for each menu and the item widget for each of its items. The
+// menus are drawn above other content with op.Defer, and kept inside
+// the maximum constraints of gtx where possible. Layout doesn't take up
+// space.
+func (m *Menu) Layout(gtx layout.Context, background layout.Widget, item MenuItemWidget) layout.Dimensions {
+	for _, e := range gtx.Events(&m.scrim) {
+		if e, ok := e.(pointer.Event); ok && e.Kind == pointer.Press {
+			m.Close()
+		}
+	}
+	m.update(gtx)
+	if !m.open {
+		return layout.Dimensions{}
+	}
+	macro := op.Record(gtx.Ops)
+	area := clip.Rect{Min: image.Pt(-inf, -inf), Max: image.Pt(inf, inf)}.Push(gtx.Ops)
+	pointer.InputOp{Tag: &m.scrim, Kinds: pointer.Press}.Add(gtx.Ops)
+	area.Pop()
+	m.layoutPopup(gtx, image.Rectangle{Max: gtx.Constraints.Max}, background, item)
+	op.Defer(gtx.Ops, macro.Stop())
+	return layout.Dimensions{}
+}
+
+// layoutPopup lays out the menu and its open submenus, kept inside bounds.
+func (m *Menu) layoutPopup(gtx layout.Context, bounds image.Rectangle, background layout.Widget, item MenuItemWidget) {
+	// Measure the items.
+	gtx.Constraints = layout.Constraints{Max: bounds.Size()}
+	width := 0
+	for i := range m.Items {
+		macro := op.Record(gtx.Ops)
+		dims := item(gtx, &m.Items[i], m.state(i))
+		macro.Stop()
+		if w := dims.Size.X; w > width {
+			width = w
+		}
+	}
+	macro := op.Record(gtx.Ops)
+	y := 0
+	for i := range m.Items {
+		it := &m.Items[i]
+		igtx := gtx
+		igtx.Constraints = layout.Constraints{
+			Min: image.Pt(width, 0),
+			Max: image.Pt(width, gtx.Constraints.Max.Y),
+		}
+		imacro := op.Record(gtx.Ops)
+		dims := item(igtx, it, m.state(i))
+		call := imacro.Stop()
+		r := image.Rect(0, y, width, y+dims.Size.Y)
+		m.items[i].rect = r
+		trans := op.Offset(r.Min).Push(gtx.Ops)
+		if !it.Separator && !it.Disabled {
+			area := clip.Rect{Max: r.Size()}.Push(gtx.Ops)
+			m.items[i].click.Add(gtx.Ops)
+			pointer.CursorPointer.Add(gtx.Ops)
+			area.Pop()
+		}
+		call.Add(gtx.Ops)
+		trans.Pop()
+		y = r.Max.Y
+	}
+	items := macro.Stop()
+
+	size := image.Pt(width, y)
+	off := menuOffset(m.anchor, size, bounds, m.parent != nil)
+	defer op.Offset(off).Push(gtx.Ops).Pop()
+	area := clip.Rect{Max: size}.Push(gtx.Ops)
+	// Block presses from reaching the scrim.
+	pointer.InputOp{Tag: m, Kinds: pointer.Press}.Add(gtx.Ops)
+	key.InputOp{Tag: m, Keys: menuKeys}.Add(gtx.Ops)
+	if m.requestFocus {
+		key.FocusOp{Tag: m}.Add(gtx.Ops)
+		m.requestFocus = false
+	}
+	bgtx := gtx
+	bgtx.Constraints = layout.Exact(size)
+	background(bgtx)
+	items.Add(gtx.Ops)
+	area.Pop()
+	for i := range m.Items {
+		if s := m.Items[i].Submenu; s != nil && s.open {
+			s.anchor = m.items[i].rect
+			s.update(gtx)
+			if s.open {
+				s.layoutPopup(gtx, bounds.Sub(off), background, item)
+			}
+		}
+	}
+}
+
+// update processes the events of the menu.
+func (m *Menu) update(gtx layout.Context) {
+	if len(m.items) != len(m.Items) {
+		m.items = make([]menuItem, len(m.Items))
+	}
+	for i := range m.items {
+		mi := &m.items[i]
+		for _, e := range mi.click.Update(gtx) {
+			switch e.Kind {
+			case gesture.KindPress:
+				if s := m.Items[i].Submenu; s != nil {
+					m.focus = i
+					if s.open {
+						s.Close()
+					} else {
+						m.openSubmenu(i, false)
+					}
+				}
+			case gesture.KindClick:
+				if m.Items[i].Submenu == nil {
+					m.activate(i)
+				}
+			}
+		}
+		h := mi.click.Hovered()
+		if h && !mi.hovered && m.open {
+			m.focus = i
+			if m.Items[i].Submenu != nil {
+				m.openSubmenu(i, false)
+			} else {
+				m.closeSubmenus()
+			}
+		}
+		mi.hovered = h
+	}
+	for _, e := range gtx.Events(m) {
+		switch e := e.(type) {
+		case key.FocusEvent:
+			m.focused = e.Focus
+		case key.Event:
+			if e.State != key.Press || !m.open {
+				break
+			}
+			m.key(e.Name)
+		}
+	}
+}
+
+func (m *Menu) key(name string) {
+	switch name {
+	case key.NameDownArrow:
+		m.moveFocus(m.focus, 1)
+	case key.NameUpArrow:
+		start := m.focus
+		if start == -1 {
+			start = len(m.Items)
+		}
+		m.moveFocus(start, -1)
+	case key.NameHome:
+		m.moveFocus(-1, 1)
+	case key.NameEnd:
+		m.moveFocus(len(m.Items), -1)
+	case key.NameRightArrow:
+		if m.focus != -1 && m.Items[m.focus].Submenu != nil {
+			m.openSubmenu(m.focus, true)
+		} else if r := m.root(); r.bar != nil {
+			r.bar.move(r, 1)
+		}
+	case key.NameLeftArrow:
+		if m.parent != nil {
+			m.Close()
+			m.parent.requestFocus = true
+		} else if m.bar != nil {
+			m.bar.move(m, -1)
+		}
+	case key.NameReturn, key.NameEnter, key.NameSpace:
+		if m.focus != -1 {
+			m.activate(m.focus)
+		}
+	case key.NameEscape:
+		m.Close()
+		if m.parent != nil {
+			m.parent.requestFocus = true
+		}
+	}
+}
+
+// moveFocus focuses the next enabled item in direction dir from start.
+func (m *Menu) moveFocus(start, dir int) {
+	for i := start + dir; i >= 0 && i < len(m.Items); i += dir {
+		if it := m.Items[i]; !it.Separator && !it.Disabled {
+			m.focus = i
+			return
+		}
+	}
+}
+
+// activate item i, opening its submenu or reporting it and closing the
+// menus.
+func (m *Menu) activate(i int) {
+	it := &m.Items[i]
+	if it.Disabled || it.Separator {
+		return
+	}
+	if it.Submenu != nil {
+		m.openSubmenu(i, true)
+		return
+	}
+	if it.Checkable {
+		it.Checked = !it.Checked
+	}
+	r := m.root()
+	r.activated = append(r.activated, it)
+	r.Close()
+}
+
+// openSubmenu opens the submenu of item i and closes the others. If
+// focus is set, the submenu takes the keyboard focus.
+func (m *Menu) openSubmenu(i int, focus bool) {
+	s := m.Items[i].Submenu
+	if s.open {
+		if focus {
+			s.requestFocus = true
+			if s.focus == -1 {
+				s.moveFocus(-1, 1)
+			}
+		}
+		return
+	}
+	m.closeSubmenus()
+	s.parent = m
+	s.open = true
+	s.focus = -1
+	s.requestFocus = focus
+	if focus {
+		s.moveFocus(-1, 1)
+	}
+}
+
+func (m *Menu) closeSubmenus() {
+	for i := range m.Items {
+		if s := m.Items[i].Submenu; s != nil && s.open {
+			s.Close()
+		}
+	}
+}
+
+func (m *Menu) root() *Menu {
+	for m.parent != nil {
+		m = m.parent
+	}
+	return m
+}
+
+func (m *Menu) state(i int) MenuItemState {
+	s := MenuItemState{Focused: i == m.focus}
+	if i < len(m.items) {
+		s.Pressed = m.items[i].click.Pressed()
+	}
+	if sub := m.Items[i].Submenu; sub != nil {
+		s.Open = sub.open
+	}
+	return s
+}
+
+// menuOffset returns the offset of a menu of the given size next to
+// anchor: below it and aligned with its left edge, or, if beside is
+// set, to the right of it and aligned with its top edge. The menu is
+// flipped to the other side of the anchor or aligned with its other
+// edge if that keeps it inside bounds.
+func menuOffset(anchor image.Rectangle, size image.Point, bounds image.Rectangle, beside bool) image.Point {
+	axis := layout.Vertical
+	if beside {
+		axis = layout.Horizontal
+	}
+	amin, amax := axis.Convert(anchor.Min), axis.Convert(anchor.Max)
+	bmin, bmax := axis.Convert(bounds.Min), axis.Convert(bounds.Max)
+	sz := axis.Convert(size)
+	off := image.Pt(amax.X, amin.Y)
+	if off.X+sz.X > bmax.X && amin.X-sz.X >= bmin.X {
+		off.X = amin.X - sz.X
+	}
+	if off.Y+sz.Y > bmax.Y {
+		off.Y = amax.Y - sz.Y
+		if beside {
+			// Submenus are aligned with the bottom of bounds
+			// instead.
+			off.Y = bmax.Y - sz.Y
+		}
+	}
+	if off.Y < bmin.Y {
+		off.Y = bmin.Y
+	}
+	return axis.Convert(off)
+}
+
+// Open returns the index of the open menu of the menu bar, or -1.
+func (b *MenuBar) Open() int {
+	for i := range b.Items {
+		if s := b.Items[i].Submenu; s != nil && s.open {
+			return i
+		}
+	}
+	return -1
+}
+
+// Layout the menu bar with the title widget for each item, and the open
+// menu with the background and item widgets as described for
+// Menu.Layout.
+func (b *MenuBar) Layout(gtx layout.Context, title MenuItemWidget, background layout.Widget, item MenuItemWidget) layout.Dimensions {
+	if len(b.items) != len(b.Items) {
+		b.items = make([]menuItem, len(b.Items))
+	}
+	for i := range b.items {
+		bi := &b.items[i]
+		for _, e := range bi.click.Update(gtx) {
+			if e.Kind != gesture.KindPress {
+				continue
+			}
+			if s := b.Items[i].Submenu; s != nil {
+				if s.open {
+					s.Close()
+				} else {
+					b.openMenu(i)
+				}
+			}
+		}
+		// Switch menus by hovering while a menu is open.
+		h := bi.click.Hovered()
+		if h && !bi.hovered {
+			if o := b.Open(); o != -1 && o != i && b.Items[i].Submenu != nil {
+				b.Items[o].Submenu.Close()
+				b.openMenu(i)
+			}
+		}
+		bi.hovered = h
+	}
+	open := b.Open()
+	cgtx := gtx
+	cgtx.Constraints.Min = image.Pt(0, gtx.Constraints.Min.Y)
+	size := image.Point{}
+	for i := range b.Items {
+		it := &b.Items[i]
+		state := MenuItemState{
+			Pressed: b.items[i].click.Pressed(),
+			Focused: b.items[i].click.Hovered(),
+			Open:    i == open,
+		}
+		macro := op.Record(gtx.Ops)
+		dims := title(cgtx, it, state)
+		call := macro.Stop()
+		r := image.Rectangle{Min: image.Pt(size.X, 0), Max: image.Pt(size.X+dims.Size.X, dims.Size.Y)}
+		b.items[i].rect = r
+		trans := op.Offset(r.Min).Push(gtx.Ops)
+		if open == -1 {
+			b.addArea(gtx, i)
+		}
+		call.Add(gtx.Ops)
+		trans.Pop()
+		size.X = r.Max.X
+		if h := dims.Size.Y; h > size.Y {
+			size.Y = h
+		}
+		cgtx.Constraints.Max.X -= dims.Size.X
+		if cgtx.Constraints.Max.X < 0 {
+			cgtx.Constraints.Max.X = 0
+		}
+	}
+	for i := range b.Items {
+		if s := b.Items[i].Submenu; s != nil {
+			s.Layout(gtx, background, item)
+		}
+	}
+	if open != -1 {
+		// Keep the titles above the scrim of the open menu, for
+		// switching menus by hovering.
+		macro := op.Record(gtx.Ops)
+		for i := range b.Items {
+			trans := op.Offset(b.items[i].rect.Min).Push(gtx.Ops)
+			b.addArea(gtx, i)
+			trans.Pop()
+		}
+		op.Defer(gtx.Ops, macro.Stop())
+	}
+	return layout.Dimensions{Size: gtx.Constraints.Constrain(size)}
+}
+
+// addArea adds the pointer area of item i.
+func (b *MenuBar) addArea(gtx layout.Context, i int) {
+	if b.Items[i].Disabled {
+		return
+	}
+	area := clip.Rect{Max: b.items[i].rect.Size()}.Push(gtx.Ops)
+	b.items[i].click.Add(gtx.Ops)
+	pointer.CursorPointer.Add(gtx.Ops)
+	area.Pop()
+}
+
+func (b *MenuBar) openMenu(i int) {
+	s := b.Items[i].Submenu
+	s.bar = b
+	s.Open(b.items[i].rect)
+	s.moveFocus(-1, 1)
+}
+
+// move opens the menu dir items away from the open menu m.
+func (b *MenuBar) move(m *Menu, dir int) {
+	cur := -1
+	for i := range b.Items {
+		if b.Items[i].Submenu == m {
+			cur = i
+		}
+	}
+	if cur == -1 {
+		return
+	}
+	n := len(b.Items)
+	for i := (cur + dir + n) % n; i != cur; i = (i + dir + n) % n {
+		if it := b.Items[i]; it.Submenu != nil && !it.Disabled {
+			m.Close()
+			b.openMenu(i)
+			return
+		}
+	}
+}
+
+// Layout the content widget, and the menu with the background and item
+// widgets as described for Menu.Layout.
+func (c *ContextArea) Layout(gtx layout.Context, w layout.Widget, background layout.Widget, item MenuItemWidget) layout.Dimensions {
+	c.click.Buttons = pointer.ButtonSecondary
+	c.click.LongPressDuration = contextLongPress
+	for _, e := range c.click.Update(gtx) {
+		if e.Kind == gesture.KindPress && e.Source == pointer.Mouse {
+			c.Menu.Open(image.Rectangle{Min: e.Position, Max: e.Position})
+		}
+	}
+	if e, ok := c.click.LongPress(gtx.Metric, gtx.Ops, gtx.Now); ok {
+		c.Menu.Open(image.Rectangle{Min: e.Position, Max: e.Position})
+	}
+	macro := op.Record(gtx.Ops)
+	dims := w(gtx)
+	call := macro.Stop()
+	area := clip.Rect{Max: dims.Size}.Push(gtx.Ops)
+	c.click.Add(gtx.Ops)
+	call.Add(gtx.Ops)
+	area.Pop()
+	c.Menu.Layout(gtx, background, item)
+	return dims
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget_test
+
+import (
+	"image"
+	"testing"
+
+	"gioui.org/f32"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/io/router"
+	"gioui.org/io/system"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+)
+
+func TestMenu(t *testing.T) {
+	var (
+		ops op.Ops
+		r   router.Router
+	)
+	sub := &widget.Menu{Items: []widget.MenuItem{
+		{Label: "Word wrap", Checkable: true},
+	}}
+	m := &widget.Menu{Items: []widget.MenuItem{
+		{Label: "Open"},
+		{Separator: true},
+		{Label: "Disabled", Disabled: true},
+		{Label: "View", Submenu: sub},
+	}}
+	gtx := layout.NewContext(&ops, system.FrameEvent{Queue: &r})
+	gtx.Constraints = layout.Exact(image.Pt(400, 400))
+	item := func(gtx layout.Context, it *widget.MenuItem, s widget.MenuItemState) layout.Dimensions {
+		return layout.Dimensions{Size: gtx.Constraints.Constrain(image.Pt(100, 20))}
+	}
+	bg := func(gtx layout.Context) layout.Dimensions {
+		return layout.Dimensions{Size: gtx.Constraints.Min}
+	}
+	frame := func() {
+		ops.Reset()
+		m.Layout(gtx, bg, item)
+		r.Frame(gtx.Ops)
+	}
+	press := func(name string) {
+		r.Queue(key.Event{Name: name, State: key.Press})
+		frame()
+	}
+
+	m.Open(image.Rect(10, 10, 50, 30))
+	frame()
+	frame()
+	if !m.Focused() {
+		t.Fatal("opened menu not focused")
+	}
+	// Focus "View", skipping the separator and the disabled item,
+	// and open its submenu.
+	press(key.NameDownArrow)
+	press(key.NameDownArrow)
+	press(key.NameRightArrow)
+	if !sub.Opened() {
+		t.Fatal("submenu not opened by right arrow")
+	}
+	frame()
+	if m.Focused() || !sub.Focused() {
+		t.Error("focus not moved to the submenu opened by right arrow")
+	}
+	press(key.NameReturn)
+	it, ok := m.Activated()
+	if !ok || it.Label != "Word wrap" || !it.Checked {
+		t.Fatalf("got activated item %v, %v; want checked Word wrap", it, ok)
+	}
+	if m.Opened() || sub.Opened() {
+		t.Fatal("menus open after activation")
+	}
+
+	// Click the first item. The menu is placed below the anchor.
+	m.Open(image.Rect(10, 10, 50, 30))
+	frame()
+	r.Queue(
+		pointer.Event{Kind: pointer.Press, Source: pointer.Mouse, Buttons: pointer.ButtonPrimary, Position: f32.Pt(20, 40)},
+		pointer.Event{Kind: pointer.Release, Source: pointer.Mouse, Position: f32.Pt(20, 40)},
+	)
+	frame()
+	if it, ok := m.Activated(); !ok || it.Label != "Open" {
+		t.Fatalf("got activated item %v, %v; want Open", it, ok)
+	}
+
+	// Press outside.
+	m.Open(image.Rect(10, 10, 50, 30))
+	frame()
+	r.Queue(
+		pointer.Event{Kind: pointer.Press, Source: pointer.Mouse, Buttons: pointer.ButtonPrimary, Position: f32.Pt(300, 300)},
+		pointer.Event{Kind: pointer.Release, Source: pointer.Mouse, Position: f32.Pt(300, 300)},
+	)
+	frame()
+	if m.Opened() {
+		t.Error("menu open after press outside")
+	}
+	if _, ok := m.Activated(); ok {
+		t.Error("item activated by press outside")
+	}
+
+	// The menu flips above an anchor at the bottom.
+	m.Open(image.Rect(10, 380, 50, 400))
+	frame()
+	r.Queue(
+		pointer.Event{Kind: pointer.Press, Source: pointer.Mouse, Buttons: pointer.ButtonPrimary, Position: f32.Pt(20, 380-4*20+10)},
+		pointer.Event{Kind: pointer.Release, Source: pointer.Mouse, Position: f32.Pt(20, 380-4*20+10)},
+	)
+	frame()
+	if it, ok := m.Activated(); !ok || it.Label != "Open" {
+		t.Fatalf("got activated item %v, %v; want Open of flipped menu", it, ok)
+	}
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget
+
+import (
+	"image"
+
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+)
+
+// Modal holds the state of a modal overlay, such as a dialog. An open
+// modal is drawn above other content, blocks pointer input to the
+// content beneath it and confines the keyboard focus to its own
+// widgets. Unless it is Persistent, it is closed by pressing Escape or
+// by pressing outside of its content.
+type Modal struct {
+	// Persistent modals are only closed by Close.
+	Persistent bool
+
+	open         bool
+	requestFocus bool
+	// scrim and content are the pointer tags for the areas outside and
+	// inside of the content.
+	scrim   bool
+	content bool
+}
+
+// Open the modal and move the keyboard focus to it.
+func (m *Modal) Open() {
+	m.open = true
+	m.requestFocus = true
+}
+
+// Close the modal.
+func (m *Modal) Close() {
+	m.open = false
+}
+
+// Opened reports whether the modal is open.
+func (m *Modal) Opened() bool {
+	return m.open
+}
+
+// Layout the open modal. The scrim widget is laid out with exact
+// constraints of the maximum constraints of gtx, and the content widget
+// is centered above it. Lay out a Modal with the constraints of the
+// window, after the widgets it covers. Layout doesn't take up space.
+func (m *Modal) Layout(gtx layout.Context, scrim, content layout.Widget) layout.Dimensions {
+	m.update(gtx)
+	if !m.open {
+		return layout.Dimensions{}
+	}
+	max := gtx.Constraints.Max
+	macro := op.Record(gtx.Ops)
+	key.FocusTrapOp{}.Add(gtx.Ops)
+	// The key handler of the modal is the ancestor of the content key
+	// handlers for receiving Escape.
+	all := clip.Rect{Min: image.Pt(-inf, -inf), Max: image.Pt(inf, inf)}
+	area := all.Push(gtx.Ops)
+	key.InputOp{Tag: m, Keys: "⎋"}.Add(gtx.Ops)
+	if m.requestFocus {
+		key.FocusOp{Tag: m}.Add(gtx.Ops)
+		m.requestFocus = false
+	}
+	// The scrim area blocks pointer input to the widgets beneath the
+	// modal. The content area is its sibling, so presses on the
+	// content don't reach the scrim handler.
+	sarea := all.Push(gtx.Ops)
+	pointer.InputOp{Tag: &m.scrim, Kinds: pointer.Press}.Add(gtx.Ops)
+	sgtx := gtx
+	sgtx.Constraints = layout.Exact(max)
+	scrim(sgtx)
+	sarea.Pop()
+
+	cgtx := gtx
+	cgtx.Constraints.Min = image.Point{}
+	cmacro := op.Record(gtx.Ops)
+	dims := content(cgtx)
+	call := cmacro.Stop()
+	off := max.Sub(dims.Size).Div(2)
+	trans := op.Offset(off).Push(gtx.Ops)
+	carea := clip.Rect{Max: dims.Size}.Push(gtx.Ops)
+	pointer.InputOp{Tag: &m.content, Kinds: pointer.Press}.Add(gtx.Ops)
+	call.Add(gtx.Ops)
+	carea.Pop()
+	trans.Pop()
+	area.Pop()
+	op.Defer(gtx.Ops, macro.Stop())
+	return layout.Dimensions{}
+}
+
+func (m *Modal) update(gtx layout.Context) {
+	for _, e := range gtx.Events(&m.scrim) {
+		if e, ok := e.(pointer.Event); ok && e.Kind == pointer.Press && !m.Persistent {
+			m.Close()
+		}
+	}
+	// Drain the presses on the content.
+	gtx.Events(&m.content)
+	for _, e := range gtx.Events(m) {
+		if e, ok := e.(key.Event); ok && e.Name == key.NameEscape && e.State == key.Press && !m.Persistent {
+			m.Close()
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget_test
+
+import (
+	"image"
+	"testing"
+
+	"gioui.org/f32"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/io/router"
+	"gioui.org/io/system"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+)
+
+func TestModal(t *testing.T) {
+	var (
+		ops      op.Ops
+		r        router.Router
+		m        widget.Modal
+		beneath  widget.Clickable
+		inside   widget.Clickable
+		clicked  bool
+		iclicked bool
+	)
+	gtx := layout.NewContext(&ops, system.FrameEvent{Queue: &r})
+	gtx.Constraints = layout.Exact(image.Pt(400, 400))
+	button := func(gtx layout.Context) layout.Dimensions {
+		return layout.Dimensions{Size: image.Pt(100, 100)}
+	}
+	frame := func() {
+		ops.Reset()
+		clicked = clicked || beneath.Clicked(gtx)
+		iclicked = iclicked || inside.Clicked(gtx)
+		beneath.Layout(gtx, button)
+		m.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			return layout.Dimensions{Size: gtx.Constraints.Min}
+		}, func(gtx layout.Context) layout.Dimensions {
+			return inside.Layout(gtx, button)
+		})
+		r.Frame(gtx.Ops)
+	}
+	click := func(x, y float32) {
+		r.Queue(
+			pointer.Event{Kind: pointer.Press, Source: pointer.Mouse, Buttons: pointer.ButtonPrimary, Position: f32.Pt(x, y)},
+			pointer.Event{Kind: pointer.Release, Source: pointer.Mouse, Position: f32.Pt(x, y)},
+		)
+		frame()
+	}
+	m.Open()
+	frame()
+	frame()
+	// The content is centered at (150, 150)-(250, 250).
+	click(50, 50)
+	if clicked {
+		t.Error("widget beneath the modal clicked")
+	}
+	if m.Opened() {
+		t.Error("modal open after press outside")
+	}
+
+	m.Open()
+	frame()
+	click(200, 200)
+	if !iclicked {
+		t.Error("modal content not clicked")
+	}
+	if !m.Opened() {
+		t.Fatal("modal closed by press on content")
+	}
+	// Focus stays inside the modal.
+	for i := 0; i < 3; i++ {
+		r.MoveFocus(router.FocusForward)
+		frame()
+		if beneath.Focused() {
+			t.Fatal("focus moved beneath the modal")
+		}
+	}
+	r.Queue(key.Event{Name: key.NameEscape, State: key.Press})
+	frame()
+	if m.Opened() {
+		t.Error("modal open after Escape")
+	}
+}
@@ -0,0 +1,228 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget
+
+import (
+	"image"
+	"math"
+	"time"
+
+	"gioui.org/internal/fling"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+)
+
+// Pager holds the state of a layout that shows one page at a time, such
+// as a carousel. Pages are changed by dragging, flinging or scrolling,
+// and the pager always settles on a page boundary.
+type Pager struct {
+	// Axis is the paging direction.
+	Axis layout.Axis
+	// Duration is the duration of page change animations. If zero, a
+	// default duration is used.
+	Duration time.Duration
+
+	// pos is the scroll position, in pages.
+	pos float32
+	// page is the current page.
+	page int
+	// start is the page at the start of a drag.
+	start int
+	// size is the page size along the axis during the last Layout.
+	size int
+
+	dragging  bool
+	grab      bool
+	pid       pointer.ID
+	last      float32
+	press     float32
+	estimator fling.Extrapolation
+	anim      pagerAnimation
+}
+
+type pagerAnimation struct {
+	active bool
+	from   float32
+	// start is the start time of the animation, or zero if it has not
+	// yet been laid out.
+	start time.Time
+}
+
+const defaultPageDuration = 300 * time.Millisecond
+
+// Page returns the current page. During page changes, it is the page the
+// pager settles on.
+func (p *Pager) Page() int {
+	return p.page
+}
+
+// Position returns the scroll position in pages. For example, a
+// position of 1.25 means that the last quarter of page 1 and the first
+// quarter of page 2 are in view.
+func (p *Pager) Position() float32 {
+	return p.pos
+}
+
+// Scrolling reports whether the pager is being dragged or is animating
+// to a page.
+func (p *Pager) Scrolling() bool {
+	return p.dragging || p.anim.active
+}
+
+// SetPage changes the current page without animation.
+func (p *Pager) SetPage(page int) {
+	p.page = page
+	p.pos = float32(page)
+	p.anim = pagerAnimation{}
+}
+
+// AnimateToPage changes the current page with an animation.
+func (p *Pager) AnimateToPage(page int) {
+	p.page = page
+	p.anim = pagerAnimation{active: true, from: p.pos}
+}
+
+// Layout the pages with n pages. Each visible page is laid out by w
+// with constraints that exactly match the maximum constraints of gtx.
+func (p *Pager) Layout(gtx layout.Context, n int, w layout.ListElement) layout.Dimensions {
+	size := gtx.Constraints.Max
+	p.size = p.Axis.Convert(size).X
+	p.update(gtx, n)
+	if n <= 0 {
+		return layout.Dimensions{Size: size}
+	}
+	p.page = clampInt(p.page, 0, n-1)
+	p.tick(gtx)
+	if p.pos < 0 {
+		p.pos = 0
+	} else if max := float32(n - 1); p.pos > max {
+		p.pos = max
+	}
+
+	defer clip.Rect{Max: size}.Push(gtx.Ops).Pop()
+	pointer.InputOp{
+		Tag:   p,
+		Grab:  p.grab,
+		Kinds: pointer.Press | pointer.Drag | pointer.Release | pointer.Scroll,
+		ScrollBounds: image.Rectangle{
+			Min: p.Axis.Convert(image.Pt(-inf, 0)),
+			Max: p.Axis.Convert(image.Pt(inf, 0)),
+		},
+	}.Add(gtx.Ops)
+
+	cgtx := gtx
+	cgtx.Constraints = layout.Exact(size)
+	first := int(math.Floor(float64(p.pos)))
+	for i := first; i <= first+1 && i < n; i++ {
+		off := int(math.Round(float64((float32(i) - p.pos) * float32(p.size))))
+		if i < 0 || off >= p.size || p.size == 0 {
+			continue
+		}
+		trans := op.Offset(p.Axis.Convert(image.Pt(off, 0))).Push(gtx.Ops)
+		w(cgtx, i)
+		trans.Pop()
+	}
+	return layout.Dimensions{Size: size}
+}
+
+func (p *Pager) update(gtx layout.Context, n int) {
+	for _, e := range gtx.Events(p) {
+		e, ok := e.(pointer.Event)
+		if !ok {
+			continue
+		}
+		val := p.Axis.FConvert(e.Position).X
+		switch e.Kind {
+		case pointer.Press:
+			if p.dragging || !(e.Buttons == pointer.ButtonPrimary || e.Source == pointer.Touch) {
+				continue
+			}
+			// Catch the pages mid-animation.
+			p.anim = pagerAnimation{}
+			p.start = int(math.Round(float64(p.pos)))
+			p.dragging = true
+			p.pid = e.PointerID
+			p.last, p.press = val, val
+			p.estimator = fling.Extrapolation{}
+			p.estimator.Sample(e.Time, val)
+		case pointer.Drag:
+			if !p.dragging || e.PointerID != p.pid {
+				continue
+			}
+			p.estimator.Sample(e.Time, val)
+			if e.Priority < pointer.Grabbed {
+				if d, slop := val-p.press, float32(gtx.Dp(touchSlop)); d >= slop || -slop >= d {
+					p.grab = true
+				}
+				continue
+			}
+			if p.size > 0 {
+				p.pos += (p.last - val) / float32(p.size)
+				p.page = int(math.Round(float64(p.pos)))
+			}
+			p.last = val
+		case pointer.Release:
+			if !p.dragging || e.PointerID != p.pid {
+				continue
+			}
+			p.settle(gtx, p.estimator.Estimate())
+		case pointer.Cancel:
+			if p.dragging {
+				p.settle(gtx, fling.Estimate{})
+			}
+		case pointer.Scroll:
+			d := p.Axis.FConvert(e.Scroll).X
+			if p.dragging || p.anim.active || d == 0 {
+				continue
+			}
+			page := p.page + 1
+			if d < 0 {
+				page = p.page - 1
+			}
+			p.AnimateToPage(clampInt(page, 0, n-1))
+		}
+	}
+}
+
+// settle ends a drag and animates to the page nearest to where the
+// fling estimated by est would come to rest, at most one page away
+// from the page the drag started on.
+func (p *Pager) settle(gtx layout.Context, est fling.Estimate) {
+	p.dragging = false
+	p.grab = false
+	target := p.pos
+	var f fling.Animation
+	if slop, d := float32(gtx.Dp(touchSlop)), est.Distance; (d < -slop || d > slop) && p.size > 0 {
+		if f.Start(gtx.Metric, gtx.Now, est.Velocity) {
+			target += f.Distance() / float32(p.size)
+		}
+	}
+	page := int(math.Round(float64(target)))
+	p.AnimateToPage(clampInt(page, p.start-1, p.start+1))
+}
+
+// tick advances the page change animation.
+func (p *Pager) tick(gtx layout.Context) {
+	if !p.anim.active {
+		return
+	}
+	if p.anim.start.IsZero() {
+		p.anim.start = gtx.Now
+	}
+	d := p.Duration
+	if d == 0 {
+		d = defaultPageDuration
+	}
+	t := float32(gtx.Now.Sub(p.anim.start)) / float32(d)
+	if t >= 1 || t < 0 {
+		p.SetPage(p.page)
+		return
+	}
+	// Ease out cubically.
+	t = 1 - t
+	t = 1 - t*t*t
+	p.pos = p.anim.from + (float32(p.page)-p.anim.from)*t
+	op.InvalidateOp{}.Add(gtx.Ops)
+}
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget_test
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"gioui.org/f32"
+	"gioui.org/io/pointer"
+	"gioui.org/io/router"
+	"gioui.org/io/system"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+)
+
+func TestPagerFling(t *testing.T) {
+	var (
+		ops   op.Ops
+		r     router.Router
+		pager widget.Pager
+	)
+	gtx := layout.NewContext(&ops, system.FrameEvent{Queue: &r, Size: image.Pt(100, 100)})
+	gtx.Now = time.Unix(0, 0)
+	page := func(gtx layout.Context, i int) layout.Dimensions {
+		return layout.Dimensions{Size: gtx.Constraints.Max}
+	}
+	frame := func() {
+		ops.Reset()
+		pager.Layout(gtx, 5, page)
+		r.Frame(gtx.Ops)
+	}
+	frame()
+	// Flick a fifth of a page to the left.
+	r.Queue(pointer.Event{
+		Source:   pointer.Touch,
+		Kind:     pointer.Press,
+		Position: f32.Pt(60, 50),
+	})
+	for i := 1; i <= 4; i++ {
+		r.Queue(pointer.Event{
+			Source:   pointer.Touch,
+			Kind:     pointer.Move,
+			Position: f32.Pt(60-float32(i)*5, 50),
+			Time:     time.Duration(i) * 10 * time.Millisecond,
+		})
+		frame()
+	}
+	if pos := pager.Position(); pos <= 0 || pos >= 0.5 {
+		t.Fatalf("position %v after drag, want within (0, 0.5)", pos)
+	}
+	r.Queue(pointer.Event{
+		Source:   pointer.Touch,
+		Kind:     pointer.Release,
+		Position: f32.Pt(40, 50),
+		Time:     50 * time.Millisecond,
+	})
+	frame()
+	if got := pager.Page(); got != 1 {
+		t.Errorf("fling settles on page %d, want 1", got)
+	}
+	gtx.Now = gtx.Now.Add(time.Second)
+	frame()
+	if got := pager.Position(); got != 1 {
+		t.Errorf("position %v after fling, want 1", got)
+	}
+}
+
+func TestPagerAnimateToPage(t *testing.T) {
+	var (
+		ops   op.Ops
+		pager = widget.Pager{Duration: 100 * time.Millisecond}
+	)
+	gtx := layout.Context{
+		Ops:         &ops,
+		Constraints: layout.Exact(image.Pt(100, 100)),
+		Now:         time.Unix(0, 0),
+	}
+	var laidOut []int
+	page := func(gtx layout.Context, i int) layout.Dimensions {
+		laidOut = append(laidOut, i)
+		return layout.Dimensions{Size: gtx.Constraints.Max}
+	}
+	pager.AnimateToPage(3)
+	pager.Layout(gtx, 5, page)
+	gtx.Now = gtx.Now.Add(50 * time.Millisecond)
+	laidOut = laidOut[:0]
+	pager.Layout(gtx, 5, page)
+	if pos := pager.Position(); pos <= 1 || pos >= 3 {
+		t.Errorf("position %v halfway through the animation", pos)
+	}
+	if len(laidOut) != 2 {
+		t.Errorf("laid out pages %v, want two pages", laidOut)
+	}
+	gtx.Now = gtx.Now.Add(50 * time.Millisecond)
+	pager.Layout(gtx, 5, page)
+	if pos, page := pager.Position(), pager.Page(); pos != 3 || page != 3 {
+		t.Errorf("position %v, page %d after animation, want 3", pos, page)
+	}
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget
+
+import (
+	"gioui.org/layout"
+	"gioui.org/unit"
+)
+
+// PullToRefresh tracks the pull-to-refresh gesture of a layout.List:
+// dragging the list beyond its start by more than a threshold
+// distance and releasing it triggers a refresh.
+type PullToRefresh struct {
+	// Threshold is the distance the list must be pulled to trigger a
+	// refresh. If zero, a default threshold is used.
+	Threshold unit.Dp
+	// Refreshing is set when a refresh is triggered. Clear it when the
+	// refresh completes.
+	Refreshing bool
+
+	// pull is the pull distance during the last Update.
+	pull int
+	// armed tracks whether the threshold was exceeded by the current
+	// drag.
+	armed bool
+}
+
+// defaultRefreshThreshold is the refresh threshold if the
+// PullToRefresh Threshold is zero.
+const defaultRefreshThreshold = unit.Dp(80)
+
+// Update the state from the overscroll of list and report whether a
+// refresh was triggered. Refreshes are not triggered while Refreshing
+// is set.
+func (p *PullToRefresh) Update(gtx layout.Context, list *layout.List) bool {
+	p.pull = 0
+	if o := list.Overscroll(); o < 0 {
+		p.pull = -o
+	}
+	if p.Refreshing {
+		p.armed = false
+		return false
+	}
+	if list.Dragging() {
+		p.armed = p.pull >= gtx.Dp(p.threshold())
+		return false
+	}
+	if !p.armed {
+		return false
+	}
+	p.armed = false
+	p.Refreshing = true
+	return true
+}
+
+// Pulled returns the distance the list is pulled, relative to the
+// refresh threshold. A value of 1 or greater means that releasing the
+// list triggers a refresh.
+func (p *PullToRefresh) Pulled(gtx layout.Context) float32 {
+	return float32(p.pull) / float32(gtx.Dp(p.threshold()))
+}
+
+// Armed reports whether releasing the list triggers a refresh.
+func (p *PullToRefresh) Armed() bool {
+	return p.armed
+}
+
+func (p *PullToRefresh) threshold() unit.Dp {
+	if p.Threshold == 0 {
+		return defaultRefreshThreshold
+	}
+	return p.Threshold
+}
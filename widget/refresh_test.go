@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget_test
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"gioui.org/f32"
+	"gioui.org/io/pointer"
+	"gioui.org/io/router"
+	"gioui.org/io/system"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+)
+
+func TestPullToRefresh(t *testing.T) {
+	var (
+		ops     op.Ops
+		r       router.Router
+		list    = layout.List{Axis: layout.Vertical}
+		refresh = widget.PullToRefresh{Threshold: 30}
+	)
+	gtx := layout.NewContext(&ops, system.FrameEvent{Queue: &r, Size: image.Pt(100, 100), Now: time.Now()})
+	triggered := false
+	frame := func() {
+		ops.Reset()
+		list.Layout(gtx, 10, func(gtx layout.Context, i int) layout.Dimensions {
+			return layout.Dimensions{Size: image.Pt(100, 20)}
+		})
+		if refresh.Update(gtx, &list) {
+			if triggered {
+				t.Error("refresh triggered twice")
+			}
+			triggered = true
+		}
+		r.Frame(gtx.Ops)
+	}
+	drag := func(kind pointer.Kind, y float32) {
+		r.Queue(pointer.Event{Kind: kind, Source: pointer.Touch, Position: f32.Pt(50, y)})
+		frame()
+	}
+	frame()
+	drag(pointer.Press, 10)
+	drag(pointer.Move, 30)
+	if refresh.Armed() {
+		t.Error("armed before reaching the threshold")
+	}
+	drag(pointer.Move, 50)
+	if !refresh.Armed() {
+		t.Error("not armed after reaching the threshold")
+	}
+	if triggered {
+		t.Error("refresh triggered before release")
+	}
+	drag(pointer.Release, 50)
+	if !triggered || !refresh.Refreshing {
+		t.Error("refresh not triggered after release")
+	}
+}
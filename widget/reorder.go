@@ -0,0 +1,467 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget
+
+import (
+	"image"
+	"math"
+	"sort"
+	"time"
+
+	"gioui.org/f32"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/unit"
+)
+
+// ReorderList holds the state of a layout.List whose items can be
+// rearranged by dragging. A drag starts when an item is long pressed
+// or when the handle of an item is pressed. During a drag the other
+// items animate out of the way of the dragged item, and the list
+// scrolls when the dragged item is near one of its edges.
+type ReorderList struct {
+	layout.List
+	// LongPressDuration is the duration of the press that starts a drag
+	// of an item. If zero, a default duration is used. If negative,
+	// items can only be dragged by their handles.
+	LongPressDuration time.Duration
+	// OnMove is called when the item at index from is dropped at index
+	// to. It must move the item in the underlying data before the next
+	// Layout.
+	OnMove func(from, to int)
+
+	drag reorderDrag
+	// items tracks the pointer state of laid out items.
+	items map[int]*reorderItem
+	// shifts are the animated offsets of displaced items.
+	shifts map[int]float32
+	// slots are the display slots laid out during the last Layout.
+	slots []reorderSlot
+	// scroll is the fractional auto-scroll distance.
+	scroll float32
+	// viewport is the main axis size of the list during the last
+	// Layout.
+	viewport int
+	last     time.Time
+}
+
+// reorderDrag is the state of a drag in progress.
+type reorderDrag struct {
+	active bool
+	// from is the index of the dragged item, and to is the index
+	// it is dropped at.
+	from, to int
+	// source is the press that started the drag.
+	source *reorderPress
+	// pos is the main axis position of the dragged item relative to
+	// the list, and base is its position at the start of the frame.
+	pos, base int
+	// size is the main axis size of the dragged item.
+	size int
+	// slot is the position of the drop slot during the last Layout.
+	slot int
+}
+
+type reorderItem struct {
+	area, handle reorderPress
+	used         bool
+}
+
+type reorderPress struct {
+	pressed bool
+	// moved is set when the pointer moved too far for a long press.
+	moved bool
+	pid   pointer.ID
+	start f32.Point
+	at    time.Time
+}
+
+type reorderSlot struct {
+	index     int
+	pos, size int
+}
+
+const (
+	defaultLongPress = 500 * time.Millisecond
+	touchSlop        = unit.Dp(3)
+	// reorderEdge is the size of the areas near the list edges that
+	// trigger auto-scrolling.
+	reorderEdge = unit.Dp(48)
+	// reorderSpeed is the maximum auto-scroll speed, per second.
+	reorderSpeed = unit.Dp(1000)
+	// reorderRelaxation is the time constant of item animations.
+	reorderRelaxation = 50 * time.Millisecond
+)
+
+// Dragged returns the index of the item being dragged, if any.
+func (r *ReorderList) Dragged() (int, bool) {
+	return r.drag.from, r.drag.active
+}
+
+// Handle lays out w as the drag handle of the item at index. Pressing
+// a handle immediately starts a drag of its item. Call Handle from the
+// element function passed to Layout.
+func (r *ReorderList) Handle(gtx layout.Context, index int, w layout.Widget) layout.Dimensions {
+	it := r.item(index)
+	dims := w(gtx)
+	defer clip.Rect{Max: dims.Size}.Push(gtx.Ops).Pop()
+	cursor := pointer.CursorGrab
+	if r.drag.active && r.drag.source == &it.handle {
+		cursor = pointer.CursorGrabbing
+	}
+	cursor.Add(gtx.Ops)
+	pointer.InputOp{
+		Tag:   &it.handle,
+		Grab:  r.drag.active && r.drag.source == &it.handle,
+		Kinds: pointer.Press | pointer.Drag | pointer.Release,
+	}.Add(gtx.Ops)
+	return dims
+}
+
+// Layout the list. The element function w is called with the index
+// of items in the underlying data, which is only changed by OnMove.
+func (r *ReorderList) Layout(gtx layout.Context, n int, w layout.ListElement) layout.Dimensions {
+	r.update(gtx)
+	if r.drag.active && r.drag.from >= n {
+		r.drag = reorderDrag{}
+	}
+
+	r.slots = r.slots[:0]
+	dims := r.List.Layout(gtx, n, func(gtx layout.Context, j int) layout.Dimensions {
+		var dims layout.Dimensions
+		if r.drag.active && j == r.drag.to {
+			dims.Size = gtx.Constraints.Constrain(r.Axis.Convert(image.Pt(r.drag.size, 0)))
+		} else {
+			dims = r.layoutItem(gtx, r.drag.item(j), w)
+		}
+		r.slots = append(r.slots, reorderSlot{index: j, size: r.Axis.Convert(dims.Size).X})
+		return dims
+	})
+	r.viewport = r.Axis.Convert(dims.Size).X
+	r.positionSlots()
+
+	if r.drag.active {
+		r.layoutDragged(gtx, dims.Size, w)
+		r.updateDrop(n)
+	}
+	for _, s := range r.shifts {
+		if s != 0 {
+			op.InvalidateOp{}.Add(gtx.Ops)
+			break
+		}
+	}
+	return dims
+}
+
+// update processes pointer events and advances animations.
+func (r *ReorderList) update(gtx layout.Context) {
+	var dt time.Duration
+	if !r.last.IsZero() {
+		dt = gtx.Now.Sub(r.last)
+	}
+	r.last = gtx.Now
+	r.drag.base = r.drag.pos
+
+	for idx, it := range r.items {
+		r.updatePress(gtx, idx, &it.area, false)
+		r.updatePress(gtx, idx, &it.handle, true)
+	}
+	for idx, it := range r.items {
+		if !it.used && !(r.drag.active && r.drag.from == idx) {
+			delete(r.items, idx)
+			continue
+		}
+		it.used = false
+		if r.drag.active || r.LongPressDuration < 0 {
+			continue
+		}
+		if p := &it.area; p.pressed && !p.moved {
+			d := r.LongPressDuration
+			if d == 0 {
+				d = defaultLongPress
+			}
+			if gtx.Now.Sub(p.at) >= d {
+				r.startDrag(idx, p)
+			} else {
+				op.InvalidateOp{At: p.at.Add(d)}.Add(gtx.Ops)
+			}
+		}
+	}
+
+	decay := float32(math.Exp(-float64(dt) / float64(reorderRelaxation)))
+	for i, s := range r.shifts {
+		s *= decay
+		if s > -0.5 && s < 0.5 {
+			delete(r.shifts, i)
+			continue
+		}
+		r.shifts[i] = s
+	}
+
+	if r.drag.active {
+		r.autoScroll(gtx, dt)
+	}
+}
+
+func (r *ReorderList) updatePress(gtx layout.Context, index int, p *reorderPress, handle bool) {
+	for _, e := range gtx.Events(p) {
+		e, ok := e.(pointer.Event)
+		if !ok {
+			continue
+		}
+		switch e.Kind {
+		case pointer.Press:
+			if !(e.Buttons == pointer.ButtonPrimary || e.Source == pointer.Touch) || p.pressed {
+				continue
+			}
+			*p = reorderPress{pressed: true, pid: e.PointerID, start: e.Position, at: gtx.Now}
+			if handle && !r.drag.active {
+				r.startDrag(index, p)
+			}
+		case pointer.Drag:
+			if !p.pressed || e.PointerID != p.pid {
+				continue
+			}
+			d := e.Position.Sub(p.start)
+			if r.drag.active && r.drag.source == p {
+				// Positions are relative to the dragged item as it was
+				// laid out in the last frame.
+				r.drag.pos = r.drag.base + int(math.Round(float64(r.Axis.FConvert(d).X)))
+				continue
+			}
+			if slop := float32(gtx.Dp(touchSlop)); d.X*d.X+d.Y*d.Y > slop*slop {
+				p.moved = true
+			}
+		case pointer.Release:
+			if !p.pressed || e.PointerID != p.pid {
+				continue
+			}
+			p.pressed = false
+			if r.drag.active && r.drag.source == p {
+				r.drop(false)
+			}
+		case pointer.Cancel:
+			p.pressed = false
+			if r.drag.active && r.drag.source == p {
+				r.drop(true)
+			}
+		}
+	}
+}
+
+func (r *ReorderList) startDrag(index int, p *reorderPress) {
+	r.drag = reorderDrag{active: true, from: index, to: index, source: p}
+	// Without a drag, display slots match item indices.
+	for _, s := range r.slots {
+		if s.index == index {
+			r.drag.pos = s.pos + int(math.Round(float64(r.shifts[index])))
+			r.drag.base = r.drag.pos
+			r.drag.size = s.size
+			r.drag.slot = s.pos
+		}
+	}
+	delete(r.shifts, index)
+}
+
+// drop ends the drag and reports the move, unless cancel is set.
+func (r *ReorderList) drop(cancel bool) {
+	d := r.drag
+	r.drag = reorderDrag{}
+	if cancel || d.from == d.to {
+		if s := float32(d.pos - d.slot); s != 0 && !cancel {
+			r.setShift(d.from, s)
+		}
+		return
+	}
+	// Re-index the item state to match the moved data.
+	move := func(i int) int {
+		switch {
+		case i == d.from:
+			return d.to
+		case d.from < d.to && i > d.from && i <= d.to:
+			return i - 1
+		case d.to < d.from && i >= d.to && i < d.from:
+			return i + 1
+		}
+		return i
+	}
+	shifts := make(map[int]float32, len(r.shifts))
+	for i, s := range r.shifts {
+		shifts[move(i)] = s
+	}
+	r.shifts = shifts
+	items := make(map[int]*reorderItem, len(r.items))
+	for i, it := range r.items {
+		items[move(i)] = it
+	}
+	r.items = items
+	r.setShift(d.to, float32(d.pos-d.slot))
+	if r.OnMove != nil {
+		r.OnMove(d.from, d.to)
+	}
+}
+
+func (r *ReorderList) setShift(index int, s float32) {
+	if r.shifts == nil {
+		r.shifts = make(map[int]float32)
+	}
+	r.shifts[index] = s
+}
+
+// autoScroll scrolls the list when the dragged item is near its edges.
+func (r *ReorderList) autoScroll(gtx layout.Context, dt time.Duration) {
+	edge := gtx.Dp(reorderEdge)
+	if edge <= 0 || dt <= 0 {
+		return
+	}
+	var v float32
+	switch {
+	case r.drag.pos < edge && (r.Position.First > 0 || r.Position.Offset > 0):
+		v = -float32(edge-r.drag.pos) / float32(edge)
+	case r.drag.pos+r.drag.size > r.viewport-edge && r.Position.BeforeEnd:
+		v = float32(r.drag.pos+r.drag.size-(r.viewport-edge)) / float32(edge)
+	default:
+		r.scroll = 0
+		return
+	}
+	if v < -1 {
+		v = -1
+	} else if v > 1 {
+		v = 1
+	}
+	r.scroll += v * float32(gtx.Dp(reorderSpeed)) * float32(dt.Seconds())
+	whole := int(r.scroll)
+	r.scroll -= float32(whole)
+	r.Position.Offset += whole
+	op.InvalidateOp{}.Add(gtx.Ops)
+}
+
+// positionSlots sorts the laid out slots and computes their positions.
+func (r *ReorderList) positionSlots() {
+	sort.Slice(r.slots, func(i, j int) bool {
+		return r.slots[i].index < r.slots[j].index
+	})
+	first := -1
+	for i, s := range r.slots {
+		if s.index == r.Position.First {
+			first = i
+			break
+		}
+	}
+	if first == -1 {
+		return
+	}
+	pos := -r.Position.Offset
+	for i := first; i < len(r.slots); i++ {
+		r.slots[i].pos = pos
+		pos += r.slots[i].size
+	}
+	pos = -r.Position.Offset
+	for i := first - 1; i >= 0; i-- {
+		pos -= r.slots[i].size
+		r.slots[i].pos = pos
+	}
+}
+
+// layoutItem lays out the item at index, displaced by its animation
+// offset, and adds its long press area.
+func (r *ReorderList) layoutItem(gtx layout.Context, index int, w layout.ListElement) layout.Dimensions {
+	macro := op.Record(gtx.Ops)
+	dims := w(gtx, index)
+	call := macro.Stop()
+	it := r.item(index)
+
+	shift := int(math.Round(float64(r.shifts[index])))
+	defer op.Offset(r.Axis.Convert(image.Pt(shift, 0))).Push(gtx.Ops).Pop()
+	call.Add(gtx.Ops)
+	defer clip.Rect{Max: dims.Size}.Push(gtx.Ops).Pop()
+	defer pointer.PassOp{}.Push(gtx.Ops).Pop()
+	pointer.InputOp{
+		Tag:   &it.area,
+		Grab:  r.drag.active && r.drag.source == &it.area,
+		Kinds: pointer.Press | pointer.Drag | pointer.Release,
+	}.Add(gtx.Ops)
+	return dims
+}
+
+// layoutDragged lays out the dragged item above the list.
+func (r *ReorderList) layoutDragged(gtx layout.Context, size image.Point, w layout.ListElement) {
+	defer clip.Rect{Max: size}.Push(gtx.Ops).Pop()
+	cs := gtx.Constraints
+	cross := r.Axis.Convert(cs.Max).Y
+	gtx.Constraints = layout.Constraints{
+		Min: r.Axis.Convert(image.Pt(0, r.Axis.Convert(cs.Min).Y)),
+		Max: r.Axis.Convert(image.Pt(inf, cross)),
+	}
+	defer op.Offset(r.Axis.Convert(image.Pt(r.drag.pos, 0))).Push(gtx.Ops).Pop()
+	dims := r.layoutItem(gtx, r.drag.from, w)
+	r.drag.size = r.Axis.Convert(dims.Size).X
+}
+
+// updateDrop moves the drop slot to where the center of the dragged
+// item is, and displaces the items in between.
+func (r *ReorderList) updateDrop(n int) {
+	// Count the items before the center of the dragged item.
+	to := r.Position.First
+	if r.drag.to < to {
+		to--
+	}
+	center := r.drag.pos + r.drag.size/2
+	for _, s := range r.slots {
+		if s.index < r.Position.First {
+			continue
+		}
+		if s.index == r.drag.to {
+			r.drag.slot = s.pos
+			continue
+		}
+		if s.pos+s.size/2 < center {
+			to++
+		}
+	}
+	to = clampInt(to, 0, n-1)
+	old := r.drag.to
+	if to == old {
+		return
+	}
+	size := float32(r.drag.size)
+	for j := old + 1; j <= to; j++ {
+		i := r.drag.item(j)
+		r.setShift(i, r.shifts[i]+size)
+	}
+	for j := to; j < old; j++ {
+		i := r.drag.item(j)
+		r.setShift(i, r.shifts[i]-size)
+	}
+	r.drag.to = to
+}
+
+func (r *ReorderList) item(index int) *reorderItem {
+	if r.items == nil {
+		r.items = make(map[int]*reorderItem)
+	}
+	it, ok := r.items[index]
+	if !ok {
+		it = new(reorderItem)
+		r.items[index] = it
+	}
+	it.used = true
+	return it
+}
+
+// item maps a display slot other than the drop slot to the index of
+// the item displayed in it.
+func (d *reorderDrag) item(j int) int {
+	if !d.active {
+		return j
+	}
+	if j > d.to {
+		j--
+	}
+	if j >= d.from {
+		j++
+	}
+	return j
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget_test
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"gioui.org/f32"
+	"gioui.org/io/pointer"
+	"gioui.org/io/router"
+	"gioui.org/io/system"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+)
+
+func TestReorderListHandle(t *testing.T) {
+	var (
+		ops   op.Ops
+		r     router.Router
+		moves [][2]int
+		list  = widget.ReorderList{
+			List: layout.List{Axis: layout.Vertical},
+			OnMove: func(from, to int) {
+				moves = append(moves, [2]int{from, to})
+			},
+		}
+	)
+	gtx := layout.NewContext(&ops, system.FrameEvent{Queue: &r, Size: image.Pt(100, 200)})
+	item := func(gtx layout.Context, i int) layout.Dimensions {
+		return list.Handle(gtx, i, func(gtx layout.Context) layout.Dimensions {
+			return layout.Dimensions{Size: image.Pt(100, 10)}
+		})
+	}
+	frame := func() {
+		ops.Reset()
+		list.Layout(gtx, 10, item)
+		r.Frame(gtx.Ops)
+	}
+	frame()
+	r.Queue(pointer.Event{
+		Source:   pointer.Mouse,
+		Buttons:  pointer.ButtonPrimary,
+		Kind:     pointer.Press,
+		Position: f32.Pt(5, 5),
+	})
+	frame()
+	if i, ok := list.Dragged(); !ok || i != 0 {
+		t.Fatalf("dragged item %d (%v), want 0", i, ok)
+	}
+	// Move the center of the dragged item past the midpoints of
+	// items 1 and 2.
+	r.Queue(pointer.Event{
+		Source:   pointer.Mouse,
+		Buttons:  pointer.ButtonPrimary,
+		Kind:     pointer.Move,
+		Position: f32.Pt(5, 30),
+	})
+	frame()
+	frame()
+	r.Queue(pointer.Event{
+		Source:   pointer.Mouse,
+		Kind:     pointer.Release,
+		Position: f32.Pt(5, 5),
+	})
+	frame()
+	if _, ok := list.Dragged(); ok {
+		t.Error("drag not ended by release")
+	}
+	if len(moves) != 1 || moves[0] != [2]int{0, 2} {
+		t.Errorf("got moves %v, want [[0 2]]", moves)
+	}
+}
+
+func TestReorderListLongPress(t *testing.T) {
+	var (
+		ops  op.Ops
+		r    router.Router
+		list = widget.ReorderList{List: layout.List{Axis: layout.Vertical}}
+	)
+	gtx := layout.NewContext(&ops, system.FrameEvent{Queue: &r, Size: image.Pt(100, 200)})
+	gtx.Now = time.Unix(0, 0)
+	item := func(gtx layout.Context, i int) layout.Dimensions {
+		return layout.Dimensions{Size: image.Pt(100, 10)}
+	}
+	frame := func() {
+		ops.Reset()
+		list.Layout(gtx, 10, item)
+		r.Frame(gtx.Ops)
+	}
+	frame()
+	r.Queue(pointer.Event{
+		Source:   pointer.Touch,
+		Kind:     pointer.Press,
+		Position: f32.Pt(5, 35),
+	})
+	frame()
+	if _, ok := list.Dragged(); ok {
+		t.Fatal("drag started before the long press duration")
+	}
+	gtx.Now = gtx.Now.Add(time.Second)
+	frame()
+	if i, ok := list.Dragged(); !ok || i != 3 {
+		t.Errorf("dragged item %d (%v), want 3", i, ok)
+	}
+}
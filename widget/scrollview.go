@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget
+
+import (
+	"image"
+
+	"gioui.org/gesture"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+)
+
+// ScrollView holds the persistent state of an area that scrolls a
+// single, possibly very large, widget along both axes. Scrolling
+// is driven by mouse wheels and by touch drags and flings. A touch
+// drag scrolls along the axis it first moves along.
+type ScrollView struct {
+	// Offset is the position of the content at the top-left corner
+	// of the viewport, in pixels. It is clamped during Layout.
+	Offset image.Point
+	// Horizontal and Vertical hold the state of scrollbars along
+	// each axis.
+	Horizontal, Vertical Scrollbar
+
+	scrollX, scrollY gesture.Scroll
+	// content is the size of the content during the last Layout.
+	content image.Point
+	// viewport is the size of the view during the last Layout.
+	viewport image.Point
+}
+
+// ScrollTo scrolls the content so that p is at the top-left corner of
+// the viewport.
+func (s *ScrollView) ScrollTo(p image.Point) {
+	s.Offset = p
+}
+
+// ScrollBy scrolls the content by d pixels.
+func (s *ScrollView) ScrollBy(d image.Point) {
+	s.Offset = s.Offset.Add(d)
+}
+
+// Reveal scrolls the content by the minimal distance that brings r
+// into view. If r is larger than the viewport, its top-left corner is
+// brought into view. The viewport size of the last Layout is used.
+func (s *ScrollView) Reveal(r image.Rectangle) {
+	reveal := func(off, min, max, size int) int {
+		switch {
+		case max-min > size || min < off:
+			return min
+		case max > off+size:
+			return max - size
+		}
+		return off
+	}
+	s.Offset.X = reveal(s.Offset.X, r.Min.X, r.Max.X, s.viewport.X)
+	s.Offset.Y = reveal(s.Offset.Y, r.Min.Y, r.Max.Y, s.viewport.Y)
+}
+
+// ContentSize returns the size of the content during the last Layout.
+func (s *ScrollView) ContentSize() image.Point {
+	return s.content
+}
+
+// Viewport returns the position of the viewport relative to the content
+// along axis, expressed as a range within [0,1]. It is suitable for
+// displaying a scrollbar.
+func (s *ScrollView) Viewport(axis layout.Axis) (start, end float32) {
+	off := axis.Convert(s.Offset).X
+	content := axis.Convert(s.content).X
+	view := axis.Convert(s.viewport).X
+	if content <= 0 {
+		return 0, 1
+	}
+	start = float32(off) / float32(content)
+	end = float32(off+view) / float32(content)
+	if end > 1 {
+		end = 1
+	}
+	return start, end
+}
+
+// Scrolling reports whether the view is being dragged or is flinging.
+func (s *ScrollView) Scrolling() bool {
+	return s.scrollX.State() != gesture.StateIdle || s.scrollY.State() != gesture.StateIdle
+}
+
+// Layout the widget w in the view. The widget is laid out with zero
+// minimum constraints and unbounded maximum constraints.
+func (s *ScrollView) Layout(gtx layout.Context, w layout.Widget) layout.Dimensions {
+	delta := image.Point{
+		X: s.scrollX.Update(gtx.Metric, gtx, gtx.Now, gesture.Horizontal),
+		Y: s.scrollY.Update(gtx.Metric, gtx, gtx.Now, gesture.Vertical),
+	}
+	s.Offset = s.Offset.Add(delta)
+
+	cgtx := gtx
+	cgtx.Constraints = layout.Constraints{Max: image.Pt(inf, inf)}
+	macro := op.Record(gtx.Ops)
+	dims := w(cgtx)
+	call := macro.Stop()
+	s.content = dims.Size
+	size := gtx.Constraints.Constrain(dims.Size)
+	s.viewport = size
+
+	maxOffset := s.content.Sub(size)
+	if maxOffset.X < 0 {
+		maxOffset.X = 0
+	}
+	if maxOffset.Y < 0 {
+		maxOffset.Y = 0
+	}
+	s.Offset.X = clampInt(s.Offset.X, 0, maxOffset.X)
+	s.Offset.Y = clampInt(s.Offset.Y, 0, maxOffset.Y)
+	// Stop flings at the edges.
+	if delta.X < 0 && s.Offset.X == 0 || delta.X > 0 && s.Offset.X == maxOffset.X {
+		s.scrollX.Stop()
+	}
+	if delta.Y < 0 && s.Offset.Y == 0 || delta.Y > 0 && s.Offset.Y == maxOffset.Y {
+		s.scrollY.Stop()
+	}
+
+	defer clip.Rect{Max: size}.Push(gtx.Ops).Pop()
+	s.scrollX.Add(gtx.Ops, image.Rectangle{
+		Min: image.Pt(-s.Offset.X, 0),
+		Max: image.Pt(maxOffset.X-s.Offset.X, 0),
+	})
+	s.scrollY.Add(gtx.Ops, image.Rectangle{
+		Min: image.Pt(0, -s.Offset.Y),
+		Max: image.Pt(0, maxOffset.Y-s.Offset.Y),
+	})
+	trans := op.Offset(s.Offset.Mul(-1)).Push(gtx.Ops)
+	call.Add(gtx.Ops)
+	trans.Pop()
+	return layout.Dimensions{Size: size}
+}
+
+// inf is the maximum constraint of content in scrolling containers.
+const inf = 1e6
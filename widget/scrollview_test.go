@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget_test
+
+import (
+	"image"
+	"testing"
+
+	"gioui.org/f32"
+	"gioui.org/io/pointer"
+	"gioui.org/io/router"
+	"gioui.org/io/system"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+)
+
+func TestScrollView(t *testing.T) {
+	var (
+		ops op.Ops
+		r   router.Router
+		sv  widget.ScrollView
+	)
+	gtx := layout.NewContext(&ops, system.FrameEvent{Queue: &r, Size: image.Pt(100, 100)})
+	content := func(gtx layout.Context) layout.Dimensions {
+		return layout.Dimensions{Size: image.Pt(300, 200)}
+	}
+	frame := func() {
+		ops.Reset()
+		sv.Layout(gtx, content)
+		r.Frame(gtx.Ops)
+	}
+	frame()
+	frame()
+	r.Queue(pointer.Event{
+		Source:   pointer.Mouse,
+		Kind:     pointer.Scroll,
+		Position: f32.Pt(50, 50),
+		Scroll:   f32.Pt(30, 20),
+	})
+	frame()
+	if got, want := sv.Offset, image.Pt(30, 20); got != want {
+		t.Errorf("offset after scroll %v, want %v", got, want)
+	}
+	if start, end := sv.Viewport(layout.Horizontal); start != .1 || end != float32(130)/300 {
+		t.Errorf("horizontal viewport [%v,%v]", start, end)
+	}
+	sv.ScrollTo(image.Pt(1000, -10))
+	frame()
+	if got, want := sv.Offset, image.Pt(200, 0); got != want {
+		t.Errorf("clamped offset %v, want %v", got, want)
+	}
+	sv.Reveal(image.Rect(10, 150, 20, 160))
+	if got, want := sv.Offset, image.Pt(10, 60); got != want {
+		t.Errorf("offset after reveal %v, want %v", got, want)
+	}
+	// Revealing a visible rectangle doesn't scroll.
+	sv.Reveal(image.Rect(50, 80, 60, 90))
+	if got, want := sv.Offset, image.Pt(10, 60); got != want {
+		t.Errorf("offset after reveal %v, want %v", got, want)
+	}
+}
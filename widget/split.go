@@ -0,0 +1,300 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget
+
+import (
+	"image"
+
+	"gioui.org/gesture"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/io/semantic"
+	"gioui.org/io/system"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/unit"
+)
+
+// Split holds the state of two panes separated by a divider that can be
+// dragged to resize them. Splits nest by laying out a Split in a pane of
+// another.
+//
+// When the divider has the keyboard focus, the arrow keys along the axis
+// move it, Home and End move it to the limits, and Enter or Space
+// collapses or expands a pane, as does double clicking the divider.
+type Split struct {
+	// Axis is the axis of the panes. Horizontal splits have the first
+	// pane at the leading edge, mirrored for right-to-left locales.
+	Axis layout.Axis
+	// Ratio is the position of the divider, from -1 for the start to 1
+	// for the end. The zero value splits the space evenly.
+	Ratio float32
+	// First and Second configure the panes.
+	First, Second SplitPane
+	// Bar is the thickness of the divider. If zero, a default thickness
+	// is used.
+	Bar unit.Dp
+
+	drag      gesture.Drag
+	dragStart float32
+	click     gesture.Click
+	focused   bool
+	changed   bool
+	// first is the size of the first pane, avail the size of both panes
+	// and rtl the direction during the last Layout.
+	first int
+	avail int
+	lo    int
+	hi    int
+	rtl   bool
+}
+
+// SplitPane configures a pane of a Split.
+type SplitPane struct {
+	// Min and Max limit the size of the pane. A zero Max means no
+	// limit.
+	Min, Max unit.Dp
+	// Collapsible panes are collapsed by double clicking the divider
+	// or by the Enter key.
+	Collapsible bool
+	// Collapsed panes are hidden, and the other pane takes all the
+	// space.
+	Collapsed bool
+}
+
+// DividerState describes the state of the divider of a Split for laying
+// it out.
+type DividerState struct {
+	Axis     layout.Axis
+	Dragging bool
+	Hovered  bool
+	Focused  bool
+}
+
+const (
+	defaultSplitBar = unit.Dp(8)
+	// splitStep is the distance the arrow keys move the divider.
+	splitStep = unit.Dp(16)
+)
+
+var (
+	splitHorizontalKeys = key.Set("←|→|⇱|⇲|⏎|⌤|Space")
+	splitVerticalKeys   = key.Set("↑|↓|⇱|⇲|⏎|⌤|Space")
+)
+
+// Dragging reports whether the divider is being dragged.
+func (s *Split) Dragging() bool {
+	return s.drag.Dragging()
+}
+
+// Focused reports whether the divider has the keyboard focus.
+func (s *Split) Focused() bool {
+	return s.focused
+}
+
+// Toggle expands the collapsed pane, or collapses the first collapsible
+// pane.
+func (s *Split) Toggle() {
+	switch {
+	case s.First.Collapsed || s.Second.Collapsed:
+		s.First.Collapsed, s.Second.Collapsed = false, false
+	case s.First.Collapsible:
+		s.First.Collapsed = true
+	case s.Second.Collapsible:
+		s.Second.Collapsed = true
+	default:
+		return
+	}
+	s.changed = true
+}
+
+// Update the state and report whether the divider was moved, or a pane
+// collapsed or expanded, by user interaction.
+func (s *Split) Update(gtx layout.Context) bool {
+	if gtx.Queue == nil {
+		s.focused = false
+	}
+	axis := gesture.Horizontal
+	if s.Axis == layout.Vertical {
+		axis = gesture.Vertical
+	}
+	var last *pointer.Event
+	for _, e := range s.drag.Update(gtx.Metric, gtx, axis) {
+		e := e
+		switch e.Kind {
+		case pointer.Press:
+			s.dragStart = s.Axis.FConvert(e.Position).X
+			if e.Source == pointer.Mouse {
+				key.FocusOp{Tag: s}.Add(gtx.Ops)
+			}
+		case pointer.Drag:
+			last = &e
+		}
+	}
+	if last != nil {
+		// Drag positions are relative to the divider, which moves with
+		// the first pane. Only the last event of a frame is relative to
+		// the current position.
+		d := int(s.Axis.FConvert(last.Position).X - s.dragStart)
+		if s.rtl && s.Axis == layout.Horizontal {
+			d = -d
+		}
+		first := s.first
+		switch {
+		case s.First.Collapsed:
+			first = 0
+		case s.Second.Collapsed:
+			first = s.avail
+		}
+		s.First.Collapsed, s.Second.Collapsed = false, false
+		s.moveTo(first + d)
+	}
+	for _, e := range s.click.Update(gtx) {
+		if e.Kind == gesture.KindClick && e.NumClicks == 2 {
+			s.Toggle()
+		}
+	}
+	for _, e := range gtx.Events(s) {
+		switch e := e.(type) {
+		case key.FocusEvent:
+			s.focused = e.Focus
+		case key.Event:
+			if e.State == key.Press {
+				s.key(gtx, e.Name)
+			}
+		}
+	}
+	changed := s.changed
+	s.changed = false
+	return changed
+}
+
+func (s *Split) key(gtx layout.Context, name string) {
+	step := gtx.Dp(splitStep)
+	if s.rtl && s.Axis == layout.Horizontal {
+		step = -step
+	}
+	switch name {
+	case key.NameLeftArrow, key.NameUpArrow:
+		s.moveTo(s.first - step)
+	case key.NameRightArrow, key.NameDownArrow:
+		s.moveTo(s.first + step)
+	case key.NameHome:
+		s.moveTo(s.lo)
+	case key.NameEnd:
+		s.moveTo(s.hi)
+	case key.NameReturn, key.NameEnter, key.NameSpace:
+		s.Toggle()
+	}
+}
+
+// moveTo moves the divider to make the first pane first pixels large.
+func (s *Split) moveTo(first int) {
+	if s.avail <= 0 {
+		return
+	}
+	first = clampInt(first, s.lo, s.hi)
+	if r := 2*float32(first)/float32(s.avail) - 1; r != s.Ratio {
+		s.Ratio = r
+		s.changed = true
+	}
+}
+
+// limits computes the range of sizes of the first pane.
+func (s *Split) limits(gtx layout.Context) {
+	lo := max(gtx.Dp(s.First.Min), 0)
+	if m := gtx.Dp(s.Second.Max); m > 0 {
+		lo = max(lo, s.avail-m)
+	}
+	hi := s.avail - gtx.Dp(s.Second.Min)
+	if m := gtx.Dp(s.First.Max); m > 0 {
+		hi = min(hi, m)
+	}
+	s.lo, s.hi = min(lo, s.avail), max(hi, min(lo, s.avail))
+}
+
+// Layout the first and second panes, and the divider between them with
+// the divider widget. The panes are laid out with exact constraints, and
+// the split fills the maximum constraints.
+func (s *Split) Layout(gtx layout.Context, first, second layout.Widget, divider func(gtx layout.Context, state DividerState) layout.Dimensions) layout.Dimensions {
+	s.Update(gtx)
+	s.rtl = gtx.Locale.Direction.Progression() == system.TowardOrigin
+	size := gtx.Constraints.Max
+	main := s.Axis.Convert(size)
+	bar := defaultSplitBar
+	if s.Bar > 0 {
+		bar = s.Bar
+	}
+	barPx := min(gtx.Dp(bar), main.X)
+	s.avail = main.X - barPx
+	s.limits(gtx)
+	s.first = clampInt(int((s.Ratio+1)/2*float32(s.avail)+.5), s.lo, s.hi)
+	firstSize := s.first
+	switch {
+	case s.First.Collapsed:
+		firstSize = 0
+	case s.Second.Collapsed:
+		firstSize = s.avail
+	}
+	secondSize := s.avail - firstSize
+	// pos converts a main axis position to a point, mirroring
+	// horizontal splits in right-to-left locales.
+	pos := func(x, w int) image.Point {
+		if s.rtl && s.Axis == layout.Horizontal {
+			x = main.X - x - w
+		}
+		return s.Axis.Convert(image.Pt(x, 0))
+	}
+	pane := func(w layout.Widget, x, length int) {
+		if length <= 0 {
+			return
+		}
+		off := pos(x, length)
+		r := s.Axis.Convert(image.Pt(length, main.Y))
+		defer op.Offset(off).Push(gtx.Ops).Pop()
+		defer clip.Rect{Max: r}.Push(gtx.Ops).Pop()
+		gtx := gtx
+		gtx.Constraints = layout.Exact(r)
+		w(gtx)
+	}
+	pane(first, 0, firstSize)
+	pane(second, firstSize+barPx, secondSize)
+
+	// Lay out the divider, with an input area at least as large as the
+	// default bar.
+	off := pos(firstSize, barPx)
+	barSize := s.Axis.Convert(image.Pt(barPx, main.Y))
+	defer op.Offset(off).Push(gtx.Ops).Pop()
+	dgtx := gtx
+	dgtx.Constraints = layout.Exact(barSize)
+	divider(dgtx, DividerState{
+		Axis:     s.Axis,
+		Dragging: s.drag.Dragging(),
+		Hovered:  s.click.Hovered(),
+		Focused:  s.focused,
+	})
+	pad := max(gtx.Dp(defaultSplitBar)-barPx, 0)
+	area := image.Rectangle{
+		Min: s.Axis.Convert(image.Pt(-pad/2, 0)),
+		Max: s.Axis.Convert(image.Pt(barPx+pad-pad/2, main.Y)),
+	}
+	defer clip.Rect(area).Push(gtx.Ops).Pop()
+	enabled := gtx.Queue != nil
+	semantic.EnabledOp(enabled).Add(gtx.Ops)
+	cursor := pointer.CursorColResize
+	if s.Axis == layout.Vertical {
+		cursor = pointer.CursorRowResize
+	}
+	cursor.Add(gtx.Ops)
+	s.drag.Add(gtx.Ops)
+	s.click.Add(gtx.Ops)
+	if enabled {
+		keys := splitHorizontalKeys
+		if s.Axis == layout.Vertical {
+			keys = splitVerticalKeys
+		}
+		key.InputOp{Tag: s, Keys: keys}.Add(gtx.Ops)
+	}
+	return layout.Dimensions{Size: size}
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget_test
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"gioui.org/f32"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/io/router"
+	"gioui.org/io/system"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+)
+
+func TestSplit(t *testing.T) {
+	var (
+		ops   op.Ops
+		r     router.Router
+		inner = &widget.Split{Axis: layout.Vertical, Bar: 10}
+	)
+	s := &widget.Split{
+		Bar:    10,
+		First:  widget.SplitPane{Min: 50, Collapsible: true},
+		Second: widget.SplitPane{Min: 50},
+	}
+	gtx := layout.NewContext(&ops, system.FrameEvent{Queue: &r})
+	gtx.Constraints = layout.Exact(image.Pt(200, 100))
+	var sizes [2]int
+	pane := func(i int) layout.Widget {
+		return func(gtx layout.Context) layout.Dimensions {
+			sizes[i] = gtx.Constraints.Min.X
+			return layout.Dimensions{Size: gtx.Constraints.Min}
+		}
+	}
+	blank := func(gtx layout.Context) layout.Dimensions {
+		return layout.Dimensions{Size: gtx.Constraints.Min}
+	}
+	divider := func(gtx layout.Context, state widget.DividerState) layout.Dimensions {
+		return layout.Dimensions{Size: gtx.Constraints.Min}
+	}
+	frame := func() bool {
+		ops.Reset()
+		sizes = [2]int{}
+		changed := s.Update(gtx)
+		s.Layout(gtx, pane(0), func(gtx layout.Context) layout.Dimensions {
+			sizes[1] = gtx.Constraints.Min.X
+			return inner.Layout(gtx, blank, blank, divider)
+		}, divider)
+		r.Frame(gtx.Ops)
+		return changed
+	}
+	drag := func(from, to f32.Point) bool {
+		r.Queue(
+			pointer.Event{Kind: pointer.Press, Source: pointer.Mouse, Buttons: pointer.ButtonPrimary, Position: from},
+			pointer.Event{Kind: pointer.Move, Source: pointer.Mouse, Buttons: pointer.ButtonPrimary, Position: to},
+			pointer.Event{Kind: pointer.Release, Source: pointer.Mouse, Position: to},
+		)
+		return frame()
+	}
+	press := func(name string) {
+		r.Queue(key.Event{Name: name, State: key.Press})
+		frame()
+	}
+
+	frame()
+	if sizes[0] != 95 || sizes[1] != 95 {
+		t.Fatalf("pane sizes %v, want an even split", sizes)
+	}
+	if !drag(f32.Pt(100, 50), f32.Pt(120, 50)) {
+		t.Error("no change from dragging the divider")
+	}
+	frame()
+	if sizes[0] != 115 {
+		t.Errorf("first pane %d wide after a drag, want 115", sizes[0])
+	}
+	if !s.Focused() {
+		t.Error("divider not focused by a press")
+	}
+	press(key.NameEnd)
+	if sizes[0] != 140 {
+		t.Errorf("first pane %d wide after End, want the limit 140", sizes[0])
+	}
+	press(key.NameLeftArrow)
+	if sizes[0] != 124 {
+		t.Errorf("first pane %d wide after the left arrow, want 124", sizes[0])
+	}
+	drag(f32.Pt(128, 50), f32.Pt(10, 50))
+	frame()
+	if sizes[0] != 50 {
+		t.Errorf("first pane %d wide after dragging past the minimum, want 50", sizes[0])
+	}
+
+	// Double click to collapse.
+	for i := 0; i < 2; i++ {
+		at := time.Second + time.Duration(i)*100*time.Millisecond
+		r.Queue(
+			pointer.Event{Kind: pointer.Press, Source: pointer.Mouse, Buttons: pointer.ButtonPrimary, Position: f32.Pt(55, 50), Time: at},
+			pointer.Event{Kind: pointer.Release, Source: pointer.Mouse, Position: f32.Pt(55, 50), Time: at},
+		)
+	}
+	frame()
+	frame()
+	if !s.First.Collapsed || sizes[0] != 0 || sizes[1] != 190 {
+		t.Errorf("collapsed %v, pane sizes %v after a double click", s.First.Collapsed, sizes)
+	}
+	press(key.NameReturn)
+	if s.First.Collapsed || sizes[0] != 50 {
+		t.Errorf("collapsed %v, pane sizes %v after Enter, want the size before collapsing", s.First.Collapsed, sizes)
+	}
+
+	// Drag the divider of the nested split.
+	ratio := s.Ratio
+	drag(f32.Pt(150, 50), f32.Pt(150, 70))
+	if inner.Ratio <= 0 || s.Ratio != ratio {
+		t.Errorf("ratios %v and %v after dragging the nested divider", s.Ratio, inner.Ratio)
+	}
+
+	// The arrow keys follow the text direction.
+	gtx.Locale = system.Locale{Language: "ar", Direction: system.RTL}
+	frame()
+	r.Queue(
+		pointer.Event{Kind: pointer.Press, Source: pointer.Mouse, Buttons: pointer.ButtonPrimary, Position: f32.Pt(145, 50), Time: 5 * time.Second},
+		pointer.Event{Kind: pointer.Release, Source: pointer.Mouse, Position: f32.Pt(145, 50), Time: 5 * time.Second},
+	)
+	frame()
+	press(key.NameLeftArrow)
+	if sizes[0] != 66 {
+		t.Errorf("first pane %d wide after the left arrow in a right-to-left locale, want 66", sizes[0])
+	}
+}
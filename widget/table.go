@@ -0,0 +1,392 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget
+
+import (
+	"image"
+
+	"gioui.org/gesture"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/unit"
+)
+
+// Table holds the persistent state of a two-dimensional grid of cells
+// that scrolls along both axes. Only the visible cells are laid out,
+// so tables with many thousands of rows and columns are cheap to
+// display.
+//
+// The leading HeaderRows rows stay pinned to the top of the table and
+// the leading HeaderColumns columns stay pinned to its leading edge.
+// Vertical scrolling of the remaining rows is handled by List, while
+// horizontal scrolling of the remaining columns is handled by the
+// Table itself.
+type Table struct {
+	// HeaderRows is the number of leading rows pinned to the top of the
+	// table.
+	HeaderRows int
+	// HeaderColumns is the number of leading columns pinned to the
+	// leading edge of the table.
+	HeaderColumns int
+	// RowHeight is the height of every row. If zero, each row is as
+	// tall as its tallest visible cell.
+	RowHeight unit.Dp
+	// Columns describes the columns of the table. Layout extends
+	// Columns with automatically sized columns as needed.
+	Columns []TableColumn
+	// List scrolls the rows below the header rows. Element i of List
+	// is row HeaderRows+i of the table.
+	List layout.List
+	// OffsetX is the distance in pixels that the columns after the
+	// header columns are scrolled. It is clamped during Layout.
+	OffsetX int
+
+	scroll  gesture.Scroll
+	visible image.Rectangle
+	// headerHeight is the total height of the header rows during the
+	// last Layout.
+	headerHeight int
+	// resized tracks whether a column changed width during Layout.
+	resized bool
+}
+
+// TableColumn describes a table column.
+type TableColumn struct {
+	// Width of the column. If zero, the column is sized to fit the
+	// widest of its cells laid out so far.
+	Width unit.Dp
+	// MinWidth and MaxWidth limit the width of the column. A zero
+	// MaxWidth means no limit.
+	MinWidth, MaxWidth unit.Dp
+	// Resizable columns can be resized by dragging the trailing
+	// edge of their header cells. Resizing sets Width.
+	Resizable bool
+
+	// auto is the measured width of an automatically sized column.
+	auto int
+	// size is the width in pixels of the column during the last Layout.
+	size int
+
+	drag gesture.Drag
+	// dragStart is the pointer position and dragWidth the width of the
+	// column when a resize started.
+	dragStart float32
+	dragWidth int
+}
+
+// TableCell lays out the cell at the given row and column.
+type TableCell func(gtx layout.Context, row, col int) layout.Dimensions
+
+// resizeHandleWidth is the width of the area around the trailing edge
+// of header cells that resizes columns.
+const resizeHandleWidth = unit.Dp(8)
+
+// VisibleCells returns the cells that were visible during the last
+// Layout, excluding the pinned header rows and columns. The X
+// coordinates of the returned rectangle are column indices, the Y
+// coordinates row indices. As with image.Rectangle, Max is exclusive.
+func (t *Table) VisibleCells() image.Rectangle {
+	return t.visible
+}
+
+// ScrollToRow scrolls the table so that row is the first row after the
+// header rows.
+func (t *Table) ScrollToRow(row int) {
+	t.List.ScrollTo(row - t.HeaderRows)
+}
+
+// ScrollToColumn scrolls the table so that col is the first column after
+// the header columns. The column widths from the last Layout are used.
+func (t *Table) ScrollToColumn(col int) {
+	x := 0
+	for i := t.HeaderColumns; i < col && i < len(t.Columns); i++ {
+		x += t.Columns[i].size
+	}
+	t.OffsetX = x
+}
+
+// Layout a table of rows by cols cells, where each cell is laid out by
+// the cell function.
+func (t *Table) Layout(gtx layout.Context, rows, cols int, cell TableCell) layout.Dimensions {
+	t.update(gtx, cols)
+	t.List.Axis = layout.Vertical
+	t.resized = false
+
+	headerRows := clampInt(t.HeaderRows, 0, rows)
+	headerCols := clampInt(t.HeaderColumns, 0, cols)
+	viewport := gtx.Constraints.Max
+
+	// Compute the width of the pinned and scrolled columns and clamp
+	// the horizontal offset.
+	pinnedWidth := 0
+	for i := 0; i < headerCols; i++ {
+		pinnedWidth += t.Columns[i].size
+	}
+	scrollWidth := 0
+	for i := headerCols; i < cols; i++ {
+		scrollWidth += t.Columns[i].size
+	}
+	maxOffset := scrollWidth - (viewport.X - pinnedWidth)
+	if maxOffset < 0 {
+		maxOffset = 0
+	}
+	t.OffsetX = clampInt(t.OffsetX, 0, maxOffset)
+
+	// Determine the range of visible scrolled columns.
+	firstCol, lastCol := cols, cols
+	x := 0
+	for i := headerCols; i < cols; i++ {
+		w := t.Columns[i].size
+		// Lay out empty columns at the leading edge to measure them.
+		leading := x+w > t.OffsetX || w == 0 && x >= t.OffsetX
+		if leading && x < t.OffsetX+viewport.X-pinnedWidth {
+			if firstCol == cols {
+				firstCol = i
+			}
+			lastCol = i + 1
+		}
+		x += w
+	}
+	firstX := 0
+	for i := headerCols; i < firstCol; i++ {
+		firstX += t.Columns[i].size
+	}
+	width := pinnedWidth + scrollWidth
+	if width > viewport.X {
+		width = viewport.X
+	}
+	if width < gtx.Constraints.Min.X {
+		width = gtx.Constraints.Min.X
+	}
+	g := tableGeometry{
+		headerCols:  headerCols,
+		firstCol:    firstCol,
+		lastCol:     lastCol,
+		pinnedWidth: pinnedWidth,
+		width:       width,
+		startX:      pinnedWidth + firstX - t.OffsetX,
+	}
+
+	macro := op.Record(gtx.Ops)
+	// Lay out header rows.
+	headerHeight := 0
+	for r := 0; r < headerRows; r++ {
+		rgtx := gtx
+		rgtx.Constraints.Min = image.Point{}
+		rgtx.Constraints.Max.Y = viewport.Y - headerHeight
+		if rgtx.Constraints.Max.Y < 0 {
+			rgtx.Constraints.Max.Y = 0
+		}
+		trans := op.Offset(image.Pt(0, headerHeight)).Push(gtx.Ops)
+		dims := t.layoutRow(rgtx, g, r, cell)
+		trans.Pop()
+		headerHeight += dims.Size.Y
+	}
+	t.headerHeight = headerHeight
+	// Lay out the scrolled rows.
+	lgtx := gtx
+	lgtx.Constraints.Min = image.Point{X: width}
+	lgtx.Constraints.Max = image.Point{X: width, Y: viewport.Y - headerHeight}
+	if lgtx.Constraints.Max.Y < 0 {
+		lgtx.Constraints.Max.Y = 0
+	}
+	if min := gtx.Constraints.Min.Y - headerHeight; min > 0 {
+		lgtx.Constraints.Min.Y = min
+	}
+	trans := op.Offset(image.Pt(0, headerHeight)).Push(gtx.Ops)
+	listDims := t.List.Layout(lgtx, rows-headerRows, func(gtx layout.Context, i int) layout.Dimensions {
+		return t.layoutRow(gtx, g, headerRows+i, cell)
+	})
+	trans.Pop()
+	// Add column resize handles on top of the header rows.
+	if headerRows > 0 {
+		t.addResizeHandles(gtx, g)
+	}
+	call := macro.Stop()
+
+	pos := t.List.Position
+	t.visible = image.Rectangle{
+		Min: image.Pt(firstCol, headerRows+pos.First),
+		Max: image.Pt(lastCol, headerRows+pos.First+pos.Count),
+	}
+
+	size := image.Pt(width, headerHeight+listDims.Size.Y)
+	size = gtx.Constraints.Constrain(size)
+	defer clip.Rect{Max: size}.Push(gtx.Ops).Pop()
+	t.scroll.Add(gtx.Ops, image.Rectangle{
+		Min: image.Pt(-t.OffsetX, 0),
+		Max: image.Pt(maxOffset-t.OffsetX, 0),
+	})
+	call.Add(gtx.Ops)
+	if t.resized {
+		// Reposition the columns following the new widths.
+		op.InvalidateOp{}.Add(gtx.Ops)
+	}
+	return layout.Dimensions{Size: size}
+}
+
+// tableGeometry describes the horizontal layout of the columns of a
+// table during Layout.
+type tableGeometry struct {
+	headerCols        int
+	firstCol, lastCol int
+	pinnedWidth       int
+	width             int
+	// startX is the position of the first visible scrolled column.
+	startX int
+}
+
+// update processes horizontal scrolling and column resizing, and
+// computes the width of each column.
+func (t *Table) update(gtx layout.Context, cols int) {
+	for len(t.Columns) < cols {
+		t.Columns = append(t.Columns, TableColumn{})
+	}
+	t.OffsetX += t.scroll.Update(gtx.Metric, gtx, gtx.Now, gesture.Horizontal)
+	for i := range t.Columns[:cols] {
+		c := &t.Columns[i]
+		var last *pointer.Event
+		for _, e := range c.drag.Update(gtx.Metric, gtx, gesture.Horizontal) {
+			e := e
+			switch e.Kind {
+			case pointer.Press:
+				c.dragStart = e.Position.X
+				c.dragWidth = c.size
+			case pointer.Drag:
+				last = &e
+			}
+		}
+		if last != nil && c.drag.Dragging() {
+			// Drag positions are in table coordinates, so the width
+			// follows the distance from the press.
+			w := c.dragWidth + int(last.Position.X-c.dragStart)
+			c.Width = gtx.Metric.PxToDp(w)
+		}
+		c.size = c.width(gtx)
+	}
+}
+
+// width computes the width of a column in pixels.
+func (c *TableColumn) width(gtx layout.Context) int {
+	w := c.auto
+	if c.Width > 0 {
+		w = gtx.Dp(c.Width)
+	}
+	if min := gtx.Dp(c.MinWidth); w < min {
+		w = min
+	}
+	if c.MaxWidth > 0 {
+		if max := gtx.Dp(c.MaxWidth); w > max {
+			w = max
+		}
+	}
+	return w
+}
+
+// layoutRow lays out the visible cells of a row.
+func (t *Table) layoutRow(gtx layout.Context, g tableGeometry, row int, cell TableCell) layout.Dimensions {
+	type cellCall struct {
+		x    int
+		call op.CallOp
+	}
+	var cells [2][]cellCall
+	height := 0
+	if t.RowHeight > 0 {
+		height = gtx.Dp(t.RowHeight)
+	}
+	layoutCell := func(col, x int) cellCall {
+		c := &t.Columns[col]
+		cgtx := gtx
+		cgtx.Constraints = layout.Exact(image.Pt(c.size, height))
+		if t.RowHeight == 0 {
+			cgtx.Constraints.Min.Y = 0
+			cgtx.Constraints.Max.Y = gtx.Constraints.Max.Y
+		}
+		if c.Width == 0 {
+			// Measure automatically sized columns.
+			cgtx.Constraints.Max.X = gtx.Constraints.Max.X
+			if c.MaxWidth > 0 {
+				cgtx.Constraints.Max.X = gtx.Dp(c.MaxWidth)
+			}
+			if cgtx.Constraints.Max.X < c.size {
+				cgtx.Constraints.Max.X = c.size
+			}
+		}
+		macro := op.Record(gtx.Ops)
+		dims := cell(cgtx, row, col)
+		call := macro.Stop()
+		if c.Width == 0 && dims.Size.X > c.auto {
+			c.auto = dims.Size.X
+			t.resized = true
+		}
+		if t.RowHeight == 0 && dims.Size.Y > height {
+			height = dims.Size.Y
+		}
+		return cellCall{x: x, call: call}
+	}
+	x := 0
+	for col := 0; col < g.headerCols; col++ {
+		cells[0] = append(cells[0], layoutCell(col, x))
+		x += t.Columns[col].size
+	}
+	x = g.startX
+	for col := g.firstCol; col < g.lastCol; col++ {
+		cells[1] = append(cells[1], layoutCell(col, x))
+		x += t.Columns[col].size
+	}
+	// Draw the scrolled cells clipped to the area right of the pinned
+	// columns, then the pinned cells.
+	scrolled := clip.Rect{Min: image.Pt(g.pinnedWidth, 0), Max: image.Pt(g.width, height)}.Push(gtx.Ops)
+	for _, c := range cells[1] {
+		trans := op.Offset(image.Pt(c.x, 0)).Push(gtx.Ops)
+		c.call.Add(gtx.Ops)
+		trans.Pop()
+	}
+	scrolled.Pop()
+	for _, c := range cells[0] {
+		trans := op.Offset(image.Pt(c.x, 0)).Push(gtx.Ops)
+		c.call.Add(gtx.Ops)
+		trans.Pop()
+	}
+	return layout.Dimensions{Size: image.Pt(g.width, height)}
+}
+
+// addResizeHandles adds the drag areas for resizable columns at the
+// trailing edges of the header cells.
+func (t *Table) addResizeHandles(gtx layout.Context, g tableGeometry) {
+	hw := gtx.Dp(resizeHandleWidth)
+	addHandle := func(col, edge int) {
+		c := &t.Columns[col]
+		if !c.Resizable {
+			return
+		}
+		r := image.Rect(edge-hw/2, 0, edge+hw-hw/2, t.headerHeight)
+		defer clip.Rect(r).Push(gtx.Ops).Pop()
+		pointer.CursorColResize.Add(gtx.Ops)
+		c.drag.Add(gtx.Ops)
+	}
+	x := g.startX
+	scrolled := clip.Rect{Min: image.Pt(g.pinnedWidth, 0), Max: image.Pt(g.width, t.headerHeight)}.Push(gtx.Ops)
+	for col := g.firstCol; col < g.lastCol; col++ {
+		x += t.Columns[col].size
+		addHandle(col, x)
+	}
+	scrolled.Pop()
+	x = 0
+	for col := 0; col < g.headerCols; col++ {
+		x += t.Columns[col].size
+		addHandle(col, x)
+	}
+}
+
+func clampInt(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
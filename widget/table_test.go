@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget_test
+
+import (
+	"image"
+	"testing"
+
+	"gioui.org/f32"
+	"gioui.org/io/pointer"
+	"gioui.org/io/router"
+	"gioui.org/io/system"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+)
+
+func TestTableVisibleCells(t *testing.T) {
+	var (
+		ops op.Ops
+		r   router.Router
+		tbl = widget.Table{HeaderRows: 1, HeaderColumns: 1, RowHeight: 10}
+	)
+	gtx := layout.NewContext(&ops, system.FrameEvent{Queue: &r, Size: image.Pt(100, 100)})
+	laidOut := make(map[image.Point]bool)
+	cell := func(gtx layout.Context, row, col int) layout.Dimensions {
+		laidOut[image.Pt(col, row)] = true
+		return layout.Dimensions{Size: image.Pt(20, 10)}
+	}
+	frame := func() {
+		ops.Reset()
+		for k := range laidOut {
+			delete(laidOut, k)
+		}
+		tbl.Layout(gtx, 10000, 1000, cell)
+		r.Frame(gtx.Ops)
+	}
+	// The first frame measures the automatically sized columns.
+	frame()
+	frame()
+	if got, want := tbl.VisibleCells(), image.Rect(1, 1, 5, 10); got != want {
+		t.Errorf("visible cells %v, want %v", got, want)
+	}
+	if n := len(laidOut); n > 100 {
+		t.Errorf("%d cells laid out, expected only the visible cells", n)
+	}
+	tbl.OffsetX = 30
+	tbl.ScrollToRow(100)
+	frame()
+	if got, want := tbl.VisibleCells(), image.Rect(2, 100, 7, 109); got != want {
+		t.Errorf("visible cells %v, want %v", got, want)
+	}
+	// Pinned cells are always laid out.
+	for _, c := range []image.Point{{0, 0}, {0, 100}, {2, 0}} {
+		if !laidOut[c] {
+			t.Errorf("pinned cell %v not laid out", c)
+		}
+	}
+	if laidOut[image.Pt(1, 0)] {
+		t.Error("scrolled away header cell laid out")
+	}
+}
+
+func TestTableAutoSize(t *testing.T) {
+	var (
+		ops op.Ops
+		tbl widget.Table
+	)
+	gtx := layout.Context{
+		Ops:         &ops,
+		Constraints: layout.Constraints{Max: image.Pt(1000, 100)},
+	}
+	widths := []int{10, 30, 20}
+	cell := func(gtx layout.Context, row, col int) layout.Dimensions {
+		w := widths[(row+col)%len(widths)]
+		if w < gtx.Constraints.Min.X {
+			w = gtx.Constraints.Min.X
+		}
+		return layout.Dimensions{Size: image.Pt(w, 10)}
+	}
+	tbl.Layout(gtx, 3, 3, cell)
+	dims := tbl.Layout(gtx, 3, 3, cell)
+	if got, want := dims.Size, image.Pt(90, 30); got != want {
+		t.Errorf("table size %v, want %v", got, want)
+	}
+}
+
+func TestTableResizeColumn(t *testing.T) {
+	var (
+		ops op.Ops
+		r   router.Router
+		tbl = widget.Table{
+			HeaderRows: 1,
+			Columns: []widget.TableColumn{
+				{Width: 50, Resizable: true},
+				{Width: 50},
+			},
+		}
+	)
+	gtx := layout.NewContext(&ops, system.FrameEvent{Queue: &r, Size: image.Pt(200, 100)})
+	cell := func(gtx layout.Context, row, col int) layout.Dimensions {
+		return layout.Dimensions{Size: image.Pt(gtx.Constraints.Min.X, 10)}
+	}
+	frame := func() {
+		ops.Reset()
+		tbl.Layout(gtx, 5, 2, cell)
+		r.Frame(gtx.Ops)
+	}
+	frame()
+	r.Queue(pointer.Event{
+		Source:   pointer.Mouse,
+		Buttons:  pointer.ButtonPrimary,
+		Kind:     pointer.Press,
+		Position: f32.Pt(50, 5),
+	})
+	frame()
+	// Resize over several frames.
+	for _, x := range []float32{60, 70, 80} {
+		r.Queue(pointer.Event{
+			Source:   pointer.Mouse,
+			Buttons:  pointer.ButtonPrimary,
+			Kind:     pointer.Move,
+			Position: f32.Pt(x, 5),
+		})
+		frame()
+		if got := tbl.Columns[0].Width; float32(got) != x {
+			t.Errorf("column width %v after dragging to %v, want %v", got, x, x)
+		}
+	}
+	if got := tbl.Columns[1].Width; got != 50 {
+		t.Errorf("non-resized column changed width to %v", got)
+	}
+}
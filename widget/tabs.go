@@ -0,0 +1,305 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget
+
+import (
+	"image"
+	"math"
+	"time"
+
+	"gioui.org/animation"
+	"gioui.org/gesture"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/io/semantic"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+)
+
+// Tabs holds the state of a tab bar, a row of tabs of which one is
+// selected. A tab is selected by clicking it, or with the arrow keys
+// when the tab bar has the keyboard focus. The tab bar scrolls when
+// its tabs don't fit.
+type Tabs struct {
+	// Selected is the index of the selected tab.
+	Selected int
+	// Closable tabs have close buttons. Clicks of close buttons are
+	// reported by Closed.
+	Closable bool
+	// List lays out the tabs. Its Axis is ignored.
+	List layout.List
+	// Duration is the duration of the selection indicator animation.
+	// If zero, the indicator doesn't animate.
+	Duration time.Duration
+
+	tabs    []tabState
+	closed  []int
+	focused bool
+	// changed tracks whether Selected was changed by user interaction
+	// since the last Update.
+	changed bool
+	// scroll tracks whether the selected tab should be scrolled into
+	// view during the next Layout.
+	scroll bool
+
+	// selected is the selected tab during the last Layout, for
+	// detecting changes of Selected.
+	selected int
+	// from is the difference between the bounds of the indicator at
+	// the start of its animation and the bounds of the selected tab.
+	from image.Rectangle
+	anim animation.Player
+}
+
+type tabState struct {
+	click gesture.Click
+	close Clickable
+	// bounds of the tab in the tab bar during the last Layout, valid
+	// if visible.
+	bounds  image.Rectangle
+	visible bool
+}
+
+// TabState describes the state of a tab for laying it out.
+type TabState struct {
+	Selected bool
+	// Focused reports whether the tab is selected and the tab bar has
+	// the keyboard focus.
+	Focused bool
+	Hovered bool
+	Pressed bool
+	// Close is the state of the close button, or nil if the tabs are
+	// not Closable.
+	Close *Clickable
+}
+
+// TabWidget lays out the tab at index with its state.
+type TabWidget func(gtx layout.Context, index int, state TabState) layout.Dimensions
+
+var tabsKeys = key.Set("←|→|⇱|⇲")
+
+// Update the state and report whether Selected was changed by user
+// interaction.
+func (t *Tabs) Update(gtx layout.Context) bool {
+	if gtx.Queue == nil {
+		t.focused = false
+	}
+	for i := range t.tabs {
+		s := &t.tabs[i]
+		for _, e := range s.click.Update(gtx) {
+			switch e.Kind {
+			case gesture.KindPress:
+				if e.Source == pointer.Mouse {
+					key.FocusOp{Tag: t}.Add(gtx.Ops)
+				}
+			case gesture.KindClick:
+				t.selectTab(i)
+			}
+		}
+		for s.close.Clicked(gtx) {
+			t.closed = append(t.closed, i)
+		}
+	}
+	for _, e := range gtx.Events(t) {
+		switch e := e.(type) {
+		case key.FocusEvent:
+			t.focused = e.Focus
+		case key.Event:
+			if e.State != key.Press || !t.focused || len(t.tabs) == 0 {
+				break
+			}
+			switch e.Name {
+			case key.NameLeftArrow:
+				t.selectTab(t.Selected - 1)
+			case key.NameRightArrow:
+				t.selectTab(t.Selected + 1)
+			case key.NameHome:
+				t.selectTab(0)
+			case key.NameEnd:
+				t.selectTab(len(t.tabs) - 1)
+			}
+		}
+	}
+	changed := t.changed
+	t.changed = false
+	return changed
+}
+
+func (t *Tabs) selectTab(i int) {
+	i = clampInt(i, 0, len(t.tabs)-1)
+	if i != t.Selected {
+		t.Selected = i
+		t.changed = true
+	}
+	t.scroll = true
+}
+
+// Focused reports whether the tab bar has the keyboard focus.
+func (t *Tabs) Focused() bool {
+	return t.focused
+}
+
+// Closed returns the index of a tab whose close button was clicked, and
+// removes it from the pending close requests. Closed tabs are not
+// removed until Remove is called.
+func (t *Tabs) Closed() (int, bool) {
+	if len(t.closed) == 0 {
+		return 0, false
+	}
+	i := t.closed[0]
+	t.closed = t.closed[1:]
+	return i, true
+}
+
+// Remove the state of the tab at index, such as after its close button
+// was clicked. If the tab was selected, the tab that takes its place is
+// selected.
+func (t *Tabs) Remove(index int) {
+	if index < 0 || index >= len(t.tabs) {
+		return
+	}
+	t.tabs = append(t.tabs[:index], t.tabs[index+1:]...)
+	closed := t.closed[:0]
+	for _, i := range t.closed {
+		switch {
+		case i > index:
+			closed = append(closed, i-1)
+		case i < index:
+			closed = append(closed, i)
+		}
+	}
+	t.closed = closed
+	if t.Selected > index || t.Selected == len(t.tabs) {
+		t.Selected--
+	}
+	if t.Selected < 0 {
+		t.Selected = 0
+	}
+	// Don't animate the indicator to the tab taking the place of the
+	// removed tab.
+	t.selected = t.Selected
+}
+
+// Indicator returns the bounds of the selection indicator in the tab
+// bar: the bounds of the selected tab, or while the selection changes,
+// bounds moving from the previously selected tab. It returns false if
+// the selected tab is scrolled out of view. Call Indicator after
+// Layout.
+func (t *Tabs) Indicator(gtx layout.Context) (image.Rectangle, bool) {
+	if t.Selected < 0 || t.Selected >= len(t.tabs) {
+		return image.Rectangle{}, false
+	}
+	s := t.tabs[t.Selected]
+	if !s.visible {
+		return image.Rectangle{}, false
+	}
+	progress := t.anim.Value(gtx, animation.Tween{From: 0, To: 1, Duration: t.Duration, Curve: animation.EaseInOut})
+	scale := func(v int) int {
+		return int(math.Round(float64(float32(v) * (1 - progress))))
+	}
+	b := s.bounds
+	return image.Rectangle{
+		Min: image.Pt(b.Min.X+scale(t.from.Min.X), b.Min.Y),
+		Max: image.Pt(b.Max.X+scale(t.from.Max.X), b.Max.Y),
+	}, true
+}
+
+// Layout n tabs in a row. Layout doesn't clear pending close requests,
+// so Closed and Remove must be called before the tabs are reduced.
+func (t *Tabs) Layout(gtx layout.Context, n int, w TabWidget) layout.Dimensions {
+	if n < 0 {
+		n = 0
+	}
+	if len(t.tabs) < n {
+		t.tabs = append(t.tabs, make([]tabState, n-len(t.tabs))...)
+	}
+	t.tabs = t.tabs[:n]
+	t.Update(gtx)
+	if n > 0 {
+		t.Selected = clampInt(t.Selected, 0, n-1)
+	}
+	t.animate(gtx)
+	if t.scroll {
+		t.List.ScrollToAligned(t.Selected, layout.ScrollNearest)
+		t.scroll = false
+	}
+	t.List.Axis = layout.Horizontal
+	for i := range t.tabs {
+		t.tabs[i].visible = false
+	}
+
+	m := op.Record(gtx.Ops)
+	dims := t.List.Layout(gtx, n, func(gtx layout.Context, i int) layout.Dimensions {
+		return t.layoutTab(gtx, i, w)
+	})
+	call := m.Stop()
+	// Compute the bounds of the visible tabs.
+	x := -t.List.Position.Offset
+	for i := t.List.Position.First; i < t.List.Position.First+t.List.Position.Count && i < n; i++ {
+		s := &t.tabs[i]
+		s.bounds = s.bounds.Add(image.Pt(x-s.bounds.Min.X, -s.bounds.Min.Y))
+		s.visible = true
+		x = s.bounds.Max.X
+	}
+
+	defer clip.Rect{Max: dims.Size}.Push(gtx.Ops).Pop()
+	if gtx.Queue != nil {
+		key.InputOp{Tag: t, Keys: tabsKeys}.Add(gtx.Ops)
+	}
+	call.Add(gtx.Ops)
+	return dims
+}
+
+// animate starts the indicator animation when Selected has changed.
+func (t *Tabs) animate(gtx layout.Context) {
+	if t.selected == t.Selected {
+		return
+	}
+	prev, sel := t.selected, t.Selected
+	t.selected = t.Selected
+	if t.Duration == 0 || prev < 0 || prev >= len(t.tabs) || sel >= len(t.tabs) || !t.tabs[prev].visible || !t.tabs[sel].visible {
+		t.from = image.Rectangle{}
+		t.anim.Reset()
+		return
+	}
+	// Start from the current indicator, relative to the selected tab
+	// so the indicator follows scrolling during the animation.
+	from, _ := t.indicatorAt(gtx, prev)
+	b := t.tabs[sel].bounds
+	t.from = image.Rectangle{Min: from.Min.Sub(b.Min), Max: from.Max.Sub(b.Max)}
+	t.anim.Start(gtx.Now)
+}
+
+// indicatorAt returns the current indicator bounds, with the tab at
+// index as the selected tab.
+func (t *Tabs) indicatorAt(gtx layout.Context, index int) (image.Rectangle, bool) {
+	sel := t.Selected
+	t.Selected = index
+	b, ok := t.Indicator(gtx)
+	t.Selected = sel
+	return b, ok
+}
+
+func (t *Tabs) layoutTab(gtx layout.Context, i int, w TabWidget) layout.Dimensions {
+	s := &t.tabs[i]
+	state := TabState{
+		Selected: i == t.Selected,
+		Focused:  i == t.Selected && t.focused,
+		Hovered:  s.click.Hovered(),
+		Pressed:  s.click.Pressed(),
+	}
+	if t.Closable {
+		state.Close = &s.close
+	}
+	gtx.Constraints.Min.X = 0
+	m := op.Record(gtx.Ops)
+	dims := w(gtx, i, state)
+	call := m.Stop()
+	s.bounds = image.Rectangle{Max: dims.Size}
+	defer clip.Rect{Max: dims.Size}.Push(gtx.Ops).Pop()
+	semantic.SelectedOp(state.Selected).Add(gtx.Ops)
+	s.click.Add(gtx.Ops)
+	call.Add(gtx.Ops)
+	return dims
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget_test
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"gioui.org/f32"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/io/router"
+	"gioui.org/io/system"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+)
+
+func TestTabs(t *testing.T) {
+	var (
+		ops  op.Ops
+		r    router.Router
+		tabs = widget.Tabs{Closable: true, Duration: 100 * time.Millisecond}
+	)
+	n := 10
+	gtx := layout.NewContext(&ops, system.FrameEvent{Queue: &r})
+	gtx.Constraints = layout.Exact(image.Pt(250, 40))
+	gtx.Now = time.Unix(0, 0)
+	// Tabs are 100 pixels wide, with a 20 pixel close button at
+	// their right edge.
+	tab := func(gtx layout.Context, i int, s widget.TabState) layout.Dimensions {
+		defer op.Offset(image.Pt(80, 0)).Push(gtx.Ops).Pop()
+		s.Close.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			return layout.Dimensions{Size: image.Pt(20, 40)}
+		})
+		return layout.Dimensions{Size: image.Pt(100, 40)}
+	}
+	frame := func() bool {
+		ops.Reset()
+		changed := tabs.Update(gtx)
+		tabs.Layout(gtx, n, tab)
+		r.Frame(gtx.Ops)
+		return changed
+	}
+	click := func(x float32) {
+		r.Queue(
+			pointer.Event{Kind: pointer.Press, Source: pointer.Mouse, Buttons: pointer.ButtonPrimary, Position: f32.Pt(x, 20)},
+			pointer.Event{Kind: pointer.Release, Source: pointer.Mouse, Position: f32.Pt(x, 20)},
+		)
+	}
+
+	frame()
+	click(150)
+	if !frame() || tabs.Selected != 1 {
+		t.Fatalf("selected tab %d after click, want 1", tabs.Selected)
+	}
+	// The indicator moves from the first tab.
+	gtx.Now = gtx.Now.Add(50 * time.Millisecond)
+	ind, ok := tabs.Indicator(gtx)
+	if !ok || ind.Min.X <= 0 || ind.Min.X >= 100 {
+		t.Errorf("indicator %v halfway through the animation", ind)
+	}
+	gtx.Now = gtx.Now.Add(time.Second)
+	if ind, _ := tabs.Indicator(gtx); ind != image.Rect(100, 0, 200, 40) {
+		t.Errorf("indicator %v after the animation, want the second tab", ind)
+	}
+
+	// The click focused the tab bar; select the last tab and scroll
+	// it into view.
+	for _, name := range []string{key.NameRightArrow, key.NameEnd} {
+		r.Queue(key.Event{Name: name, State: key.Press})
+		frame()
+	}
+	if tabs.Selected != n-1 {
+		t.Fatalf("selected tab %d after End, want %d", tabs.Selected, n-1)
+	}
+	frame()
+	if ind, ok := tabs.Indicator(gtx); !ok || ind.Max.X != 250 {
+		t.Errorf("indicator %v, %v; want the last tab scrolled into view", ind, ok)
+	}
+
+	// Close the selected last tab.
+	click(240)
+	frame()
+	i, ok := tabs.Closed()
+	if !ok || i != n-1 {
+		t.Fatalf("closed tab %d, %v; want %d", i, ok, n-1)
+	}
+	n--
+	tabs.Remove(i)
+	if tabs.Selected != n-1 {
+		t.Errorf("selected tab %d after removing the last tab, want %d", tabs.Selected, n-1)
+	}
+	if _, ok := tabs.Closed(); ok {
+		t.Error("close request reported twice")
+	}
+}
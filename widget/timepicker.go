@@ -0,0 +1,351 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gioui.org/gesture"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/io/semantic"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+)
+
+// TimePicker holds the state of a time of day input made of hour and
+// minute fields, and an AM/PM field for 12-hour clocks.
+//
+// The up and down arrow keys change the focused field, and the left and
+// right arrow keys move between fields. Typing digits enters the value
+// of the focused field, moving to the next field when the value is
+// complete. Typing A or P sets the AM/PM field. The fields are always
+// laid out from left to right, as times are in right-to-left locales.
+type TimePicker struct {
+	// Hour is the hour of the day, from 0 to 23.
+	Hour int
+	// Minute is the minute of the hour, from 0 to 59.
+	Minute int
+	// Hour12 selects the 12-hour clock with an AM/PM field.
+	Hour12 bool
+	// MinuteStep is the step of the arrow keys in the minute field. If
+	// zero, the step is one minute.
+	MinuteStep int
+
+	fields  [3]timeField
+	changed bool
+}
+
+// TimeField identifies a field of a TimePicker.
+type TimeField uint8
+
+const (
+	TimeHour TimeField = iota
+	TimeMinute
+	// TimePeriod is the AM/PM field of 12-hour clocks.
+	TimePeriod
+)
+
+// TimeFieldState describes the state of a field of a TimePicker for
+// laying it out.
+type TimeFieldState struct {
+	Field TimeField
+	// Text is the formatted value of the field.
+	Text    string
+	Focused bool
+	Hovered bool
+}
+
+// TimeFieldWidget lays out a field of a TimePicker.
+type TimeFieldWidget func(gtx layout.Context, state TimeFieldState) layout.Dimensions
+
+type timeField struct {
+	click   gesture.Click
+	focused bool
+	// digits are the digits typed into the field, and last the time
+	// of the last digit.
+	digits string
+	last   time.Time
+}
+
+var timeKeys = key.Set("↑|↓|←|→|⇱|⇲")
+
+// Focused returns the focused field, if any.
+func (p *TimePicker) Focused() (TimeField, bool) {
+	for i := range p.fields {
+		if p.fields[i].focused {
+			return TimeField(i), true
+		}
+	}
+	return 0, false
+}
+
+// Time returns the time of day on date.
+func (p *TimePicker) Time(date time.Time) time.Time {
+	y, m, d := date.Date()
+	return time.Date(y, m, d, p.Hour, p.Minute, 0, 0, date.Location())
+}
+
+// SetTime sets the hour and minute of t.
+func (p *TimePicker) SetTime(t time.Time) {
+	p.Hour, p.Minute = t.Hour(), t.Minute()
+}
+
+// Text returns the formatted value of the field f.
+func (p *TimePicker) Text(f TimeField) string {
+	switch f {
+	case TimeHour:
+		h := p.Hour
+		if p.Hour12 {
+			if h = h % 12; h == 0 {
+				h = 12
+			}
+		}
+		return fmt.Sprintf("%02d", h)
+	case TimeMinute:
+		return fmt.Sprintf("%02d", p.Minute)
+	case TimePeriod:
+		if p.Hour >= 12 {
+			return "PM"
+		}
+		return "AM"
+	}
+	return ""
+}
+
+// Update the state and report whether the time was changed by user
+// interaction.
+func (p *TimePicker) Update(gtx layout.Context) bool {
+	p.Hour = clampInt(p.Hour, 0, 23)
+	p.Minute = clampInt(p.Minute, 0, 59)
+	for i := range p.fields {
+		f := &p.fields[i]
+		if gtx.Queue == nil {
+			f.focused = false
+		}
+		for _, e := range f.click.Update(gtx) {
+			if e.Kind == gesture.KindPress && e.Source == pointer.Mouse {
+				key.FocusOp{Tag: f}.Add(gtx.Ops)
+			}
+		}
+		for _, e := range gtx.Events(f) {
+			switch e := e.(type) {
+			case key.FocusEvent:
+				f.focused = e.Focus
+				f.digits = ""
+			case key.Event:
+				if e.State == key.Press {
+					p.key(gtx, TimeField(i), e.Name)
+				}
+			case key.EditEvent:
+				for _, r := range e.Text {
+					p.edit(gtx, TimeField(i), r)
+				}
+			}
+		}
+	}
+	changed := p.changed
+	p.changed = false
+	return changed
+}
+
+func (p *TimePicker) key(gtx layout.Context, f TimeField, name string) {
+	p.fields[f].digits = ""
+	switch name {
+	case key.NameLeftArrow:
+		p.focus(gtx, f, -1)
+	case key.NameRightArrow:
+		p.focus(gtx, f, 1)
+	case key.NameUpArrow:
+		p.step(f, 1)
+	case key.NameDownArrow:
+		p.step(f, -1)
+	case key.NameHome:
+		switch f {
+		case TimeHour:
+			h := 0
+			if p.Hour12 {
+				h = p.Hour / 12 * 12
+			}
+			p.setHour(h)
+		case TimeMinute:
+			p.setMinute(0)
+		case TimePeriod:
+			p.setHour(p.Hour % 12)
+		}
+	case key.NameEnd:
+		switch f {
+		case TimeHour:
+			h := 23
+			if p.Hour12 {
+				h = p.Hour/12*12 + 11
+			}
+			p.setHour(h)
+		case TimeMinute:
+			p.setMinute(59 / p.minuteStep() * p.minuteStep())
+		case TimePeriod:
+			p.setHour(p.Hour%12 + 12)
+		}
+	}
+}
+
+// focus moves the keyboard focus dir fields from f.
+func (p *TimePicker) focus(gtx layout.Context, f TimeField, dir int) {
+	n := 2
+	if p.Hour12 {
+		n = 3
+	}
+	i := int(f) + dir
+	if i < 0 || i >= n {
+		return
+	}
+	key.FocusOp{Tag: &p.fields[i]}.Add(gtx.Ops)
+}
+
+func (p *TimePicker) minuteStep() int {
+	if p.MinuteStep <= 0 || p.MinuteStep > 60 {
+		return 1
+	}
+	return p.MinuteStep
+}
+
+// step the field f up or down, wrapping around.
+func (p *TimePicker) step(f TimeField, dir int) {
+	switch f {
+	case TimeHour:
+		p.setHour((p.Hour + dir + 24) % 24)
+	case TimeMinute:
+		s := p.minuteStep()
+		m := p.Minute
+		if dir > 0 {
+			m = (m/s + 1) * s
+		} else {
+			m = (m+s-1)/s*s - s
+		}
+		switch {
+		case m >= 60:
+			m = 0
+		case m < 0:
+			m = 59 / s * s
+		}
+		p.setMinute(m)
+	case TimePeriod:
+		p.setHour((p.Hour + 12) % 24)
+	}
+}
+
+// edit enters the typed character r into the field f.
+func (p *TimePicker) edit(gtx layout.Context, f TimeField, r rune) {
+	if f == TimePeriod {
+		switch strings.ToLower(string(r)) {
+		case "a":
+			p.setHour(p.Hour % 12)
+		case "p":
+			p.setHour(p.Hour%12 + 12)
+		}
+		return
+	}
+	if r < '0' || r > '9' {
+		return
+	}
+	fs := &p.fields[f]
+	if gtx.Now.Sub(fs.last) > typeAheadTimeout {
+		fs.digits = ""
+	}
+	fs.last = gtx.Now
+	fs.digits += string(r)
+	v := 0
+	for _, d := range fs.digits {
+		v = v*10 + int(d-'0')
+	}
+	lo, hi := 0, 59
+	if f == TimeHour {
+		lo, hi = 0, 23
+		if p.Hour12 {
+			lo, hi = 1, 12
+		}
+	}
+	if v >= lo && v <= hi {
+		p.setField(f, v)
+	}
+	// Move to the next field when no more digits fit.
+	if len(fs.digits) == 2 || v*10 > hi {
+		fs.digits = ""
+		p.focus(gtx, f, 1)
+	}
+}
+
+// setField sets the value v shown in the field f.
+func (p *TimePicker) setField(f TimeField, v int) {
+	switch f {
+	case TimeHour:
+		if p.Hour12 {
+			v = v%12 + p.Hour/12*12
+		}
+		p.setHour(v)
+	case TimeMinute:
+		p.setMinute(v)
+	}
+}
+
+func (p *TimePicker) setHour(h int) {
+	if h != p.Hour {
+		p.Hour = h
+		p.changed = true
+	}
+}
+
+func (p *TimePicker) setMinute(m int) {
+	if m != p.Minute {
+		p.Minute = m
+		p.changed = true
+	}
+}
+
+// Layout the hour and minute fields, and the AM/PM field for 12-hour
+// clocks, with the field widget, and the separator between the hour
+// and minute fields.
+func (p *TimePicker) Layout(gtx layout.Context, field TimeFieldWidget, separator layout.Widget) layout.Dimensions {
+	p.Update(gtx)
+	gtx.Constraints.Min.X = 0
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return p.layoutField(gtx, TimeHour, field)
+		}),
+		layout.Rigid(separator),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return p.layoutField(gtx, TimeMinute, field)
+		}),
+	}
+	if p.Hour12 {
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return p.layoutField(gtx, TimePeriod, field)
+		}))
+	}
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx, children...)
+}
+
+func (p *TimePicker) layoutField(gtx layout.Context, f TimeField, w TimeFieldWidget) layout.Dimensions {
+	fs := &p.fields[f]
+	m := op.Record(gtx.Ops)
+	dims := w(gtx, TimeFieldState{
+		Field:   f,
+		Text:    p.Text(f),
+		Focused: fs.focused,
+		Hovered: fs.click.Hovered(),
+	})
+	call := m.Stop()
+	defer clip.Rect{Max: dims.Size}.Push(gtx.Ops).Pop()
+	enabled := gtx.Queue != nil
+	semantic.EnabledOp(enabled).Add(gtx.Ops)
+	fs.click.Add(gtx.Ops)
+	pointer.CursorText.Add(gtx.Ops)
+	if enabled {
+		key.InputOp{Tag: fs, Keys: timeKeys}.Add(gtx.Ops)
+	}
+	call.Add(gtx.Ops)
+	return dims
+}
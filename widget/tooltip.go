@@ -0,0 +1,228 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget
+
+import (
+	"image"
+	"time"
+
+	"gioui.org/f32"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/unit"
+)
+
+// Tooltip holds the state of a tip shown for an anchor widget when a
+// mouse pointer hovers over it, or when it is long pressed by touch.
+type Tooltip struct {
+	// HoverDelay is the time a mouse pointer must hover over the
+	// anchor before the tip is shown. If zero, a default delay is used.
+	HoverDelay time.Duration
+	// LongPressDelay is the time a touch must press the anchor before
+	// the tip is shown. If zero, a default delay is used.
+	LongPressDelay time.Duration
+	// TouchDuration is the time the tip remains visible after a long
+	// press is released. If zero, a default duration is used.
+	TouchDuration time.Duration
+	// Bounds is the window area relative to the anchor, that is the
+	// window rectangle offset by the negated position of the anchor.
+	// The tip is kept inside it. Callers must set Bounds for tips to
+	// stay inside the window: if empty, the tip is kept inside the
+	// maximum constraints of the anchor instead, which don't extend to
+	// the window edges unless the anchor fills the window.
+	Bounds image.Rectangle
+
+	visible bool
+	// touched tracks whether the tip was shown by a long press.
+	touched bool
+	// hovering tracks whether a mouse pointer is over the anchor,
+	// and dismissed whether it has since pressed the anchor.
+	hovering  bool
+	dismissed bool
+	// pressing tracks whether a long press is pending.
+	pressing bool
+	pid      pointer.ID
+	press    f32.Point
+	// start is the start of the pending delay, and hide the time
+	// the tip of a released long press is hidden. Both are zero
+	// until set during a Layout.
+	start time.Time
+	hide  time.Time
+}
+
+const (
+	defaultTooltipHoverDelay     = 500 * time.Millisecond
+	defaultTooltipLongPressDelay = 500 * time.Millisecond
+	defaultTooltipTouchDuration  = 1500 * time.Millisecond
+)
+
+// tooltipGap is the distance between an anchor and its tip.
+const tooltipGap = unit.Dp(4)
+
+// Visible reports whether the tip is shown.
+func (t *Tooltip) Visible() bool {
+	return t.visible
+}
+
+// Layout the anchor widget, and the tip widget when it is visible. The
+// tip is drawn above other content with op.Defer, below the anchor or
+// above it if there is no room below. It is positioned within Bounds.
+func (t *Tooltip) Layout(gtx layout.Context, anchor, tip layout.Widget) layout.Dimensions {
+	t.update(gtx)
+	t.tick(gtx)
+	m := op.Record(gtx.Ops)
+	dims := anchor(gtx)
+	call := m.Stop()
+	area := clip.Rect{Max: dims.Size}.Push(gtx.Ops)
+	pointer.InputOp{
+		Tag:   t,
+		Kinds: pointer.Enter | pointer.Leave | pointer.Press | pointer.Drag | pointer.Release | pointer.Cancel,
+	}.Add(gtx.Ops)
+	call.Add(gtx.Ops)
+	area.Pop()
+	if !t.visible {
+		return dims
+	}
+	tgtx := gtx
+	tgtx.Constraints.Min = image.Point{}
+	m = op.Record(gtx.Ops)
+	tdims := tip(tgtx)
+	call = m.Stop()
+	bounds := t.Bounds
+	if bounds.Empty() {
+		bounds = image.Rectangle{Max: gtx.Constraints.Max}
+	}
+	off := popupOffset(image.Rectangle{Max: dims.Size}, tdims.Size, bounds, gtx.Dp(tooltipGap))
+	m = op.Record(gtx.Ops)
+	trans := op.Offset(off).Push(gtx.Ops)
+	call.Add(gtx.Ops)
+	trans.Pop()
+	op.Defer(gtx.Ops, m.Stop())
+	return dims
+}
+
+func (t *Tooltip) update(gtx layout.Context) {
+	for _, e := range gtx.Events(t) {
+		e, ok := e.(pointer.Event)
+		if !ok {
+			continue
+		}
+		touch := e.Source == pointer.Touch
+		switch e.Kind {
+		case pointer.Enter:
+			if !touch && !t.hovering {
+				t.hovering = true
+				t.start = time.Time{}
+			}
+		case pointer.Leave:
+			if !touch {
+				t.hovering = false
+				t.dismissed = false
+				if !t.touched {
+					t.visible = false
+				}
+			}
+		case pointer.Press:
+			if !touch {
+				// Clicking the anchor dismisses the tip.
+				t.dismissed = true
+				t.visible = false
+				break
+			}
+			if t.pressing {
+				break
+			}
+			t.pressing = true
+			t.pid = e.PointerID
+			t.press = e.Position
+			t.start = time.Time{}
+		case pointer.Drag:
+			if !t.pressing || e.PointerID != t.pid {
+				break
+			}
+			d := e.Position.Sub(t.press)
+			if slop := float32(gtx.Dp(touchSlop)); d.X*d.X+d.Y*d.Y > slop*slop {
+				t.pressing = false
+			}
+		case pointer.Release, pointer.Cancel:
+			if t.pressing && e.PointerID == t.pid {
+				t.pressing = false
+				t.hide = time.Time{}
+			}
+		}
+	}
+}
+
+// tick shows or hides the tip when the pending delay has passed.
+func (t *Tooltip) tick(gtx layout.Context) {
+	if t.visible {
+		if !t.touched || t.pressing {
+			return
+		}
+		if t.hide.IsZero() {
+			d := t.TouchDuration
+			if d == 0 {
+				d = defaultTooltipTouchDuration
+			}
+			t.hide = gtx.Now.Add(d)
+		}
+		if gtx.Now.Before(t.hide) {
+			op.InvalidateOp{At: t.hide}.Add(gtx.Ops)
+			return
+		}
+		t.visible = false
+		t.touched = false
+		return
+	}
+	var delay time.Duration
+	switch {
+	case t.pressing:
+		delay = t.LongPressDelay
+		if delay == 0 {
+			delay = defaultTooltipLongPressDelay
+		}
+	case t.hovering && !t.dismissed:
+		delay = t.HoverDelay
+		if delay == 0 {
+			delay = defaultTooltipHoverDelay
+		}
+	default:
+		return
+	}
+	if t.start.IsZero() {
+		t.start = gtx.Now
+	}
+	if due := t.start.Add(delay); gtx.Now.Before(due) {
+		op.InvalidateOp{At: due}.Add(gtx.Ops)
+		return
+	}
+	t.visible = true
+	t.touched = t.pressing
+	t.hide = time.Time{}
+}
+
+// popupOffset returns the offset of a popup of the given size for an
+// anchor rectangle. The popup is centered below the anchor, separated
+// by gap, or above the anchor if it doesn't fit inside bounds below it
+// and there is more room above. The popup is kept inside bounds.
+func popupOffset(anchor image.Rectangle, size image.Point, bounds image.Rectangle, gap int) image.Point {
+	off := image.Pt((anchor.Min.X+anchor.Max.X-size.X)/2, anchor.Max.Y+gap)
+	if off.Y+size.Y > bounds.Max.Y && anchor.Min.Y-bounds.Min.Y > bounds.Max.Y-anchor.Max.Y {
+		off.Y = anchor.Min.Y - gap - size.Y
+	}
+	if off.Y+size.Y > bounds.Max.Y {
+		off.Y = bounds.Max.Y - size.Y
+	}
+	if off.Y < bounds.Min.Y {
+		off.Y = bounds.Min.Y
+	}
+	if off.X+size.X > bounds.Max.X {
+		off.X = bounds.Max.X - size.X
+	}
+	if off.X < bounds.Min.X {
+		off.X = bounds.Min.X
+	}
+	return off
+}
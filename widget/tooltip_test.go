@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget_test
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"gioui.org/f32"
+	"gioui.org/io/pointer"
+	"gioui.org/io/router"
+	"gioui.org/io/system"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/widget"
+)
+
+func TestTooltip(t *testing.T) {
+	var (
+		ops op.Ops
+		r   router.Router
+		tip widget.Tooltip
+	)
+	now := time.Now()
+	gtx := layout.NewContext(&ops, system.FrameEvent{Queue: &r, Now: now})
+	gtx.Constraints = layout.Exact(image.Pt(400, 400))
+	frame := func(d time.Duration) {
+		gtx.Now = now.Add(d)
+		ops.Reset()
+		tip.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			return layout.Dimensions{Size: image.Pt(50, 50)}
+		}, func(gtx layout.Context) layout.Dimensions {
+			return layout.Dimensions{Size: image.Pt(100, 20)}
+		})
+		r.Frame(gtx.Ops)
+	}
+	frame(0)
+	r.Queue(pointer.Event{Kind: pointer.Move, Source: pointer.Mouse, Position: f32.Pt(25, 25)})
+	frame(0)
+	frame(100 * time.Millisecond)
+	if tip.Visible() {
+		t.Fatal("tip visible before the hover delay")
+	}
+	frame(time.Second)
+	if !tip.Visible() {
+		t.Fatal("tip not visible after the hover delay")
+	}
+	r.Queue(
+		pointer.Event{Kind: pointer.Press, Source: pointer.Mouse, Buttons: pointer.ButtonPrimary, Position: f32.Pt(25, 25)},
+		pointer.Event{Kind: pointer.Release, Source: pointer.Mouse, Position: f32.Pt(25, 25)},
+	)
+	frame(2 * time.Second)
+	if tip.Visible() {
+		t.Fatal("tip visible after click")
+	}
+	r.Queue(pointer.Event{Kind: pointer.Move, Source: pointer.Mouse, Position: f32.Pt(200, 200)})
+	frame(3 * time.Second)
+
+	// Long press by touch.
+	r.Queue(pointer.Event{Kind: pointer.Press, Source: pointer.Touch, Position: f32.Pt(25, 25)})
+	frame(4 * time.Second)
+	frame(5 * time.Second)
+	if !tip.Visible() {
+		t.Fatal("tip not visible after long press")
+	}
+	r.Queue(pointer.Event{Kind: pointer.Release, Source: pointer.Touch, Position: f32.Pt(25, 25)})
+	frame(5 * time.Second)
+	if !tip.Visible() {
+		t.Fatal("tip hidden immediately after long press release")
+	}
+	frame(10 * time.Second)
+	if tip.Visible() {
+		t.Error("tip visible long after long press release")
+	}
+}
+
+func TestTooltipBounds(t *testing.T) {
+	window := image.Pt(400, 400)
+	for _, tc := range []struct {
+		label  string
+		anchor image.Point
+		// want is the window position of the tip.
+		want image.Point
+	}{
+		{label: "top left", anchor: image.Pt(0, 0), want: image.Pt(0, 54)},
+		{label: "right edge", anchor: image.Pt(350, 100), want: image.Pt(300, 154)},
+		{label: "bottom edge", anchor: image.Pt(100, 350), want: image.Pt(75, 326)},
+		{label: "bottom right corner", anchor: image.Pt(350, 350), want: image.Pt(300, 326)},
+	} {
+		var (
+			ops    op.Ops
+			r      router.Router
+			tip    widget.Tooltip
+			tipTag int
+		)
+		tip.Bounds = image.Rectangle{Max: window}.Sub(tc.anchor)
+		now := time.Now()
+		gtx := layout.NewContext(&ops, system.FrameEvent{Queue: &r, Now: now})
+		frame := func(d time.Duration) {
+			gtx.Now = now.Add(d)
+			ops.Reset()
+			off := op.Offset(tc.anchor).Push(gtx.Ops)
+			gtx.Constraints = layout.Exact(image.Pt(50, 50))
+			tip.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return layout.Dimensions{Size: image.Pt(50, 50)}
+			}, func(gtx layout.Context) layout.Dimensions {
+				sz := image.Pt(100, 20)
+				defer clip.Rect{Max: sz}.Push(gtx.Ops).Pop()
+				pointer.InputOp{Tag: &tipTag, Kinds: pointer.Press}.Add(gtx.Ops)
+				return layout.Dimensions{Size: sz}
+			})
+			off.Pop()
+			r.Frame(gtx.Ops)
+		}
+		frame(0)
+		pos := layout.FPt(tc.anchor.Add(image.Pt(25, 25)))
+		r.Queue(pointer.Event{Kind: pointer.Move, Source: pointer.Mouse, Position: pos})
+		frame(0)
+		frame(time.Second)
+		if !tip.Visible() {
+			t.Fatalf("%s: tip not visible after the hover delay", tc.label)
+		}
+		// Press the corners of the expected tip area.
+		for _, p := range []image.Point{tc.want, tc.want.Add(image.Pt(99, 19))} {
+			r.Queue(pointer.Event{Kind: pointer.Press, Source: pointer.Touch, Position: layout.FPt(p).Add(f32.Pt(.5, .5))})
+			if len(r.Events(&tipTag)) == 0 {
+				t.Errorf("%s: tip not at %v", tc.label, tc.want)
+			}
+			r.Queue(pointer.Event{Kind: pointer.Release, Source: pointer.Touch, Position: layout.FPt(p)})
+		}
+	}
+}
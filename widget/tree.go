@@ -0,0 +1,354 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget
+
+import (
+	"gioui.org/gesture"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/unit"
+)
+
+// Tree holds the state of a tree view, a list of nodes that can be
+// expanded to show their children. Only the visible rows are laid out,
+// so trees with many nodes are cheap to show.
+//
+// Clicking a node selects it, and double clicking it or pressing Enter
+// activates it. A node is expanded or collapsed by clicking its
+// expander, the indentation column of its depth, by double clicking it,
+// or by the right and left arrow keys.
+type Tree struct {
+	// Roots are the top level nodes.
+	Roots []*TreeNode
+	// Load returns the children of an expanded node with nil Children.
+	// It may return nil, such as when children are loaded in the
+	// background; call Reload after setting the Children field.
+	Load func(n *TreeNode) []*TreeNode
+	// Selected is the selected node, or nil.
+	Selected *TreeNode
+	// Indent is the indentation of each depth level. If zero, a
+	// default indentation is used.
+	Indent unit.Dp
+	// List lays out the rows. Its Axis is ignored.
+	List layout.List
+
+	// rows are the visible nodes, and sel the index of the row of
+	// Selected.
+	rows  []treeRow
+	sel   int
+	dirty bool
+	// roots is the first root during the last Layout, for detecting
+	// changes of Roots.
+	roots    *TreeNode
+	nroots   int
+	visible  listRows
+	click    gesture.Click
+	focused  bool
+	changed  bool
+	scroll   bool
+	activate []*TreeNode
+}
+
+// TreeNode is a node of a Tree.
+type TreeNode struct {
+	// Value is an application value of the node, such as the path of a
+	// file.
+	Value interface{}
+	// Leaf nodes have no children.
+	Leaf bool
+	// Expanded nodes show their children.
+	Expanded bool
+	// Children are the child nodes. Children is nil until the node is
+	// loaded.
+	Children []*TreeNode
+}
+
+// TreeNodeState describes the state of a node for laying it out.
+type TreeNodeState struct {
+	// Depth is the depth of the node, zero for root nodes.
+	Depth int
+	// Expandable reports whether the node has or may have children.
+	Expandable bool
+	Selected   bool
+	// Focused reports whether the node is selected and the tree has
+	// the keyboard focus.
+	Focused bool
+}
+
+// TreeNodeWidget lays out a node with its state. The node is laid out
+// with an exact width, and should show its expander in the indentation
+// column of its depth.
+type TreeNodeWidget func(gtx layout.Context, n *TreeNode, state TreeNodeState) layout.Dimensions
+
+type treeRow struct {
+	node  *TreeNode
+	depth int
+}
+
+const defaultTreeIndent = unit.Dp(24)
+
+var treeKeys = key.Set("↑|↓|←|→|⇞|⇟|⇱|⇲|⏎|⌤")
+
+// Reload the nodes after changes to Roots or the Expanded or Children
+// fields of nodes.
+func (t *Tree) Reload() {
+	t.dirty = true
+}
+
+// Expand the node n, loading its children if necessary.
+func (t *Tree) Expand(n *TreeNode) {
+	if n.Leaf || n.Expanded {
+		return
+	}
+	if n.Children == nil && t.Load != nil {
+		n.Children = t.Load(n)
+	}
+	n.Expanded = true
+	t.dirty = true
+}
+
+// Collapse the node n. If the selected node is a descendant of n, n is
+// selected.
+func (t *Tree) Collapse(n *TreeNode) {
+	if !n.Expanded {
+		return
+	}
+	if t.Selected != nil && t.Selected != n && isDescendant(n, t.Selected) {
+		t.Selected = n
+		t.changed = true
+	}
+	n.Expanded = false
+	t.dirty = true
+}
+
+func isDescendant(n, d *TreeNode) bool {
+	for _, c := range n.Children {
+		if c == d || c.Expanded && isDescendant(c, d) {
+			return true
+		}
+	}
+	return false
+}
+
+// Focused reports whether the tree has the keyboard focus.
+func (t *Tree) Focused() bool {
+	return t.focused
+}
+
+// Activated returns the next node activated by a double click or the
+// Enter key.
+func (t *Tree) Activated() (*TreeNode, bool) {
+	if len(t.activate) == 0 {
+		return nil, false
+	}
+	n := t.activate[0]
+	t.activate = t.activate[1:]
+	return n, true
+}
+
+// Update the state and report whether Selected was changed by user
+// interaction.
+func (t *Tree) Update(gtx layout.Context) bool {
+	if gtx.Queue == nil {
+		t.focused = false
+	}
+	t.load()
+	indent := gtx.Dp(t.indent())
+	for _, e := range t.click.Update(gtx) {
+		i, ok := t.visible.at(&t.List, float32(e.Position.Y))
+		if !ok || i >= len(t.rows) {
+			continue
+		}
+		r := t.rows[i]
+		// The expander is in the indentation column of the depth
+		// of the node.
+		expander := e.Position.X >= r.depth*indent && e.Position.X < (r.depth+1)*indent
+		switch e.Kind {
+		case gesture.KindPress:
+			if e.Source == pointer.Mouse {
+				key.FocusOp{Tag: t}.Add(gtx.Ops)
+			}
+			t.selectRow(i)
+		case gesture.KindClick:
+			switch {
+			case expander:
+				t.toggle(r.node)
+			case e.NumClicks == 2:
+				t.toggle(r.node)
+				t.activate = append(t.activate, r.node)
+			}
+		}
+	}
+	for _, e := range gtx.Events(t) {
+		switch e := e.(type) {
+		case key.FocusEvent:
+			t.focused = e.Focus
+		case key.Event:
+			if e.State == key.Press {
+				t.key(e.Name)
+			}
+		}
+	}
+	changed := t.changed
+	t.changed = false
+	return changed
+}
+
+func (t *Tree) key(name string) {
+	t.load()
+	if len(t.rows) == 0 {
+		return
+	}
+	if t.Selected == nil {
+		t.selectRow(0)
+		return
+	}
+	i := t.sel
+	r := t.rows[i]
+	switch name {
+	case key.NameUpArrow:
+		t.selectRow(i - 1)
+	case key.NameDownArrow:
+		t.selectRow(i + 1)
+	case key.NamePageUp:
+		t.selectRow(i - t.page())
+	case key.NamePageDown:
+		t.selectRow(i + t.page())
+	case key.NameHome:
+		t.selectRow(0)
+	case key.NameEnd:
+		t.selectRow(len(t.rows) - 1)
+	case key.NameRightArrow:
+		switch {
+		case !r.node.Expanded:
+			t.Expand(r.node)
+		case len(r.node.Children) > 0:
+			t.selectRow(i + 1)
+		}
+	case key.NameLeftArrow:
+		if r.node.Expanded {
+			t.Collapse(r.node)
+			break
+		}
+		// Select the parent.
+		for j := i - 1; j >= 0; j-- {
+			if t.rows[j].depth < r.depth {
+				t.selectRow(j)
+				break
+			}
+		}
+	case key.NameReturn, key.NameEnter:
+		t.activate = append(t.activate, r.node)
+	}
+}
+
+func (t *Tree) page() int {
+	if n := t.List.Position.Count - 1; n > 1 {
+		return n
+	}
+	return 1
+}
+
+func (t *Tree) toggle(n *TreeNode) {
+	if n.Expanded {
+		t.Collapse(n)
+	} else {
+		t.Expand(n)
+	}
+}
+
+func (t *Tree) selectRow(i int) {
+	i = clampInt(i, 0, len(t.rows)-1)
+	t.sel = i
+	t.scroll = true
+	if n := t.rows[i].node; n != t.Selected {
+		t.Selected = n
+		t.changed = true
+	}
+}
+
+func (t *Tree) indent() unit.Dp {
+	if t.Indent == 0 {
+		return defaultTreeIndent
+	}
+	return t.Indent
+}
+
+// load flattens the expanded nodes into rows, if necessary, and finds
+// the row of the selected node.
+func (t *Tree) load() {
+	var first *TreeNode
+	if len(t.Roots) > 0 {
+		first = t.Roots[0]
+	}
+	if first != t.roots || len(t.Roots) != t.nroots {
+		t.roots, t.nroots = first, len(t.Roots)
+		t.dirty = true
+	}
+	if t.dirty {
+		t.dirty = false
+		t.rows = t.rows[:0]
+		t.flatten(t.Roots, 0)
+	}
+	if t.Selected == nil || t.sel < len(t.rows) && t.rows[t.sel].node == t.Selected {
+		return
+	}
+	t.sel = 0
+	for i, r := range t.rows {
+		if r.node == t.Selected {
+			t.sel = i
+			break
+		}
+	}
+}
+
+func (t *Tree) flatten(nodes []*TreeNode, depth int) {
+	for _, n := range nodes {
+		t.rows = append(t.rows, treeRow{node: n, depth: depth})
+		if n.Expanded && !n.Leaf {
+			if n.Children == nil && t.Load != nil {
+				n.Children = t.Load(n)
+			}
+			t.flatten(n.Children, depth+1)
+		}
+	}
+}
+
+// Layout the visible nodes with w.
+func (t *Tree) Layout(gtx layout.Context, w TreeNodeWidget) layout.Dimensions {
+	t.Update(gtx)
+	t.load()
+	if t.scroll {
+		if len(t.rows) > 0 {
+			t.List.ScrollToAligned(t.sel, layout.ScrollNearest)
+		}
+		t.scroll = false
+	}
+	t.List.Axis = layout.Vertical
+	t.visible.reset()
+	m := op.Record(gtx.Ops)
+	dims := t.List.Layout(gtx, len(t.rows), func(gtx layout.Context, i int) layout.Dimensions {
+		r := t.rows[i]
+		n := r.node
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		dims := w(gtx, n, TreeNodeState{
+			Depth:      r.depth,
+			Expandable: !n.Leaf && (n.Children == nil || len(n.Children) > 0),
+			Selected:   n == t.Selected,
+			Focused:    n == t.Selected && t.focused,
+		})
+		t.visible.add(i, dims.Size.Y)
+		return dims
+	})
+	call := m.Stop()
+	defer clip.Rect{Max: dims.Size}.Push(gtx.Ops).Pop()
+	t.click.Add(gtx.Ops)
+	if gtx.Queue != nil {
+		key.InputOp{Tag: t, Keys: treeKeys}.Add(gtx.Ops)
+	}
+	call.Add(gtx.Ops)
+	return dims
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package widget_test
+
+import (
+	"fmt"
+	"image"
+	"testing"
+
+	"gioui.org/f32"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/io/router"
+	"gioui.org/io/system"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+)
+
+func TestTree(t *testing.T) {
+	var (
+		ops   op.Ops
+		r     router.Router
+		loads int
+	)
+	tree := &widget.Tree{
+		Roots: []*widget.TreeNode{
+			{Value: "a"},
+			{Value: "b", Leaf: true},
+		},
+		Load: func(n *widget.TreeNode) []*widget.TreeNode {
+			loads++
+			var children []*widget.TreeNode
+			for i := 0; i < 100000; i++ {
+				children = append(children, &widget.TreeNode{Value: fmt.Sprintf("%v%d", n.Value, i), Leaf: true})
+			}
+			return children
+		},
+	}
+	gtx := layout.NewContext(&ops, system.FrameEvent{Queue: &r})
+	gtx.Constraints = layout.Exact(image.Pt(200, 200))
+	laidOut := 0
+	node := func(gtx layout.Context, n *widget.TreeNode, s widget.TreeNodeState) layout.Dimensions {
+		laidOut++
+		return layout.Dimensions{Size: image.Pt(gtx.Constraints.Min.X, 20)}
+	}
+	frame := func() bool {
+		ops.Reset()
+		laidOut = 0
+		changed := tree.Update(gtx)
+		tree.Layout(gtx, node)
+		r.Frame(gtx.Ops)
+		return changed
+	}
+	click := func(x, y float32) {
+		r.Queue(
+			pointer.Event{Kind: pointer.Press, Source: pointer.Mouse, Buttons: pointer.ButtonPrimary, Position: f32.Pt(x, y)},
+			pointer.Event{Kind: pointer.Release, Source: pointer.Mouse, Position: f32.Pt(x, y)},
+		)
+	}
+	press := func(name string) {
+		r.Queue(key.Event{Name: name, State: key.Press})
+		frame()
+	}
+
+	frame()
+	if loads != 0 {
+		t.Fatal("children loaded before expanding")
+	}
+	// Click the expander of the first node.
+	click(10, 10)
+	if !frame() || tree.Selected != tree.Roots[0] {
+		t.Fatal("first node not selected by a click")
+	}
+	frame()
+	if loads != 1 || !tree.Roots[0].Expanded {
+		t.Fatalf("%d loads, expanded %v after clicking the expander", loads, tree.Roots[0].Expanded)
+	}
+	if laidOut > 20 {
+		t.Errorf("%d nodes laid out, want only the visible nodes", laidOut)
+	}
+
+	// Navigate with the keyboard.
+	press(key.NameDownArrow)
+	if got := tree.Selected.Value; got != "a0" {
+		t.Errorf("selected %v after the down arrow, want a0", got)
+	}
+	press(key.NameEnd)
+	if got := tree.Selected.Value; got != "b" {
+		t.Errorf("selected %v after End, want b", got)
+	}
+	press(key.NameUpArrow)
+	press(key.NameLeftArrow)
+	if got := tree.Selected; got != tree.Roots[0] {
+		t.Errorf("selected %v after the left arrow, want the parent", got.Value)
+	}
+	press(key.NameLeftArrow)
+	if tree.Roots[0].Expanded {
+		t.Error("node not collapsed by the left arrow")
+	}
+	press(key.NameRightArrow)
+	press(key.NameRightArrow)
+	if got := tree.Selected.Value; !tree.Roots[0].Expanded || got != "a0" {
+		t.Errorf("selected %v after expanding, want a0", got)
+	}
+	if loads != 1 {
+		t.Errorf("children loaded %d times, want once", loads)
+	}
+	press(key.NameReturn)
+	if n, ok := tree.Activated(); !ok || n.Value != "a0" {
+		t.Error("node not activated by Enter")
+	}
+}
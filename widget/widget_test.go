@@ -5,6 +5,7 @@ package widget_test
 import (
 	"image"
 	"testing"
+	"time"
 
 	"gioui.org/f32"
 	"gioui.org/io/pointer"
@@ -59,3 +60,29 @@ func TestBool(t *testing.T) {
 		t.Error("click did not select")
 	}
 }
+
+func TestValueTransitionStart(t *testing.T) {
+	now := time.Now()
+	b := widget.Bool{Value: true}
+	tr := b.ValueTransition()
+	tr.Duration = time.Second
+	if p := tr.Progress(now); p != 1 {
+		t.Errorf("initial Bool transition progress %v, want 1", p)
+	}
+	tr.Set(now, false)
+	if !tr.Animating(now.Add(time.Second / 2)) {
+		t.Error("Bool transition not animating after a change")
+	}
+	e := widget.Enum{Value: "b"}
+	for _, k := range []string{"a", "b"} {
+		want := float32(0)
+		if k == e.Value {
+			want = 1
+		}
+		tr := e.ValueTransition(k)
+		tr.Duration = time.Second
+		if p := tr.Progress(now); p != want {
+			t.Errorf("initial Enum transition progress of %q %v, want %v", k, p, want)
+		}
+	}
+}